@@ -25,6 +25,27 @@ CREATE TABLE IF NOT EXISTS networks (
     ss58_prefix SMALLINT UNSIGNED DEFAULT 42,
     active BOOLEAN DEFAULT TRUE,
     last_checked_block BIGINT UNSIGNED DEFAULT 0,
+    -- Fallback existential deposit (plancks) for runtimes where the constant can't be read
+    existential_deposit VARCHAR(100),
+    -- Asset scanning overrides; NULL scans whenever the pallet is detected
+    scan_assets BOOLEAN DEFAULT NULL,
+    scan_foreign_assets BOOLEAN DEFAULT NULL,
+    scan_pool_assets BOOLEAN DEFAULT NULL,
+    -- Optional "Name: value" header lines for private RPC providers; sent over rpc_url (HTTP)
+    auth_header TEXT,
+    -- Optional PEM CA bundle path and SHA-256 SPKI pin ("sha256/<base64>" or hex) for rpc_url
+    tls_ca_file VARCHAR(255),
+    tls_cert_pin VARCHAR(100),
+    -- Genesis hash recorded on first connect; an endpoint serving another chain is refused
+    genesis_hash VARCHAR(66),
+    -- Optional Ethereum JSON-RPC endpoint (Moonbeam, Astar EVM); H160 accounts are read through it
+    evm_rpc_url VARCHAR(255),
+    -- Block reads target: best, finalized or finalized+N; NULL follows the use_finalized_head setting
+    read_target VARCHAR(32),
+    -- Sudo.Key account recorded by discovery; a change raises an operational alert
+    sudo_key VARCHAR(66),
+    -- Alert on any native transfer of at least this many whole tokens; NULL disables
+    whale_transfer_threshold VARCHAR(100),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     INDEX idx_active (active),
@@ -50,22 +71,46 @@ CREATE TABLE IF NOT EXISTS network_pallets (
 CREATE TABLE IF NOT EXISTS accounts (
     id INT AUTO_INCREMENT PRIMARY KEY,
     address VARCHAR(255) UNIQUE NOT NULL,
-    address_type ENUM('substrate', 'evm') DEFAULT 'substrate',
+    address_type ENUM('substrate', 'sr25519', 'ed25519', 'ecdsa', 'evm') DEFAULT 'substrate',
     name VARCHAR(100),
     description TEXT,
+    -- Comma separated labels for grouping accounts
+    tags VARCHAR(255),
     monitor_enabled BOOLEAN DEFAULT TRUE,
     discord_notify BOOLEAN DEFAULT TRUE,
+    -- Bitmask of enabled alert types: 1=balance, 2=low_balance, 4=slash (reserved balance cleared), 8=bounty,
+    -- 16=proxy, 32=validator, 64=identity, 128=governance, 256=approval, 512=treasury
+    notify_mask INT UNSIGNED DEFAULT 1023,
+    -- Balance read path: full, or minimal (System.Account only, at the
+    -- finalized head with retries; no asset scan or staking lookups)
+    profile ENUM('full', 'minimal') NOT NULL DEFAULT 'full',
+    -- Derivation family label; accounts sharing one are rolled up in the summary
+    family VARCHAR(100),
+    -- Last time any balance read for the account succeeded
+    last_checked TIMESTAMP NULL,
+    -- Balance change alerts are silenced until this time (set with !mute)
+    muted_until TIMESTAMP NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     INDEX idx_monitor_enabled (monitor_enabled),
     INDEX idx_address_type (address_type)
 );
 
+-- Optional per-account network list; an account with rows here is only
+-- checked on those networks, an account without any on every active network
+CREATE TABLE IF NOT EXISTS account_networks (
+    account_id INT NOT NULL,
+    network_id INT NOT NULL,
+    PRIMARY KEY (account_id, network_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (network_id) REFERENCES networks(id) ON DELETE CASCADE
+);
+
 -- Network tokens (native + assets)
 CREATE TABLE IF NOT EXISTS network_tokens (
     id INT AUTO_INCREMENT PRIMARY KEY,
     network_id INT NOT NULL,
-    token_type ENUM('native', 'asset', 'foreign_asset') DEFAULT 'native',
+    token_type ENUM('native', 'asset', 'foreign_asset', 'pool_asset', 'erc20') DEFAULT 'native',
     token_id VARCHAR(100),
     symbol VARCHAR(100),
     name VARCHAR(255),
@@ -73,6 +118,9 @@ CREATE TABLE IF NOT EXISTS network_tokens (
     pallet_name VARCHAR(100),
     metadata JSON,
     active BOOLEAN DEFAULT TRUE,
+    -- Holdings below this many tokens are left out of the daily summary;
+    -- NULL uses the summary_min_asset_balance setting
+    summary_min_balance DOUBLE NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     FOREIGN KEY (network_id) REFERENCES networks(id) ON DELETE CASCADE,
@@ -93,6 +141,9 @@ CREATE TABLE IF NOT EXISTS bounties (
     bond VARCHAR(100),
     value VARCHAR(100),
     status VARCHAR(50),
+    beneficiary VARCHAR(255),
+    update_due INT UNSIGNED,
+    unlock_at INT UNSIGNED,
     description TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -134,8 +185,7 @@ CREATE TABLE IF NOT EXISTS balances (
     network_token_id INT NOT NULL,
     free VARCHAR(100) DEFAULT '0',
     reserved VARCHAR(100) DEFAULT '0',
-    misc_frozen VARCHAR(100) DEFAULT '0',
-    fee_frozen VARCHAR(100) DEFAULT '0',
+    frozen VARCHAR(100) DEFAULT '0',
     bonded VARCHAR(100) DEFAULT '0',
     total VARCHAR(100) DEFAULT '0',
     last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -173,6 +223,31 @@ CREATE TABLE IF NOT EXISTS balance_history (
     INDEX idx_block_number (block_number)
 );
 
+-- Notifications that could not be delivered, retried by a background worker
+CREATE TABLE IF NOT EXISTS failed_notifications (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    notification_type VARCHAR(50) NOT NULL,
+    target VARCHAR(255),
+    content TEXT NOT NULL,
+    failure_reason TEXT,
+    attempts INT UNSIGNED DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at TIMESTAMP NULL DEFAULT NULL,
+    INDEX idx_created_at (created_at)
+);
+
+-- (account, network) pairs processed in the current balance cycle, so a
+-- restarted monitor resumes the cycle; cleared once the cycle completes
+CREATE TABLE IF NOT EXISTS cycle_progress (
+    cycle_id BIGINT UNSIGNED NOT NULL,
+    account_id INT NOT NULL,
+    network_id INT NOT NULL,
+    completed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cycle_id, account_id, network_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (network_id) REFERENCES networks(id) ON DELETE CASCADE
+);
+
 -- Account roles (validator, nominator, collator)
 CREATE TABLE IF NOT EXISTS account_roles (
     id INT AUTO_INCREMENT PRIMARY KEY,
@@ -244,12 +319,61 @@ INSERT INTO settings (name, value, description) VALUES
 ('guild_id', '', 'Discord guild/server ID'),
 ('alerts_channel_id', '', 'Discord channel ID for alerts'),
 ('summary_channel_id', '', 'Discord channel ID for daily summaries'),
+('changes_channel_id', '', 'Discord channel ID for the compact one line per change feed (bot only; empty disables)'),
 ('monitor_role_id', '', 'Discord role ID for monitoring notifications'),
 ('check_interval_hours', '24', 'Hours between balance checks'),
+('delta_check_interval_minutes', '0', 'Minutes between delta checks of recently changed accounts between full cycles (0 disables)'),
+('delta_active_hours', '24', 'A delta check re-reads accounts with a balance change within this many hours'),
+('notify_network_changes', 'false', 'Send an operational alert when networks are added or deactivated between balance cycles'),
 ('validator_check_interval_hours', '8', 'Hours between validator checks'),
 ('bounty_check_interval_minutes', '30', 'Minutes between bounty checks'),
+('collator_offline_sessions', '2', 'Sessions without an authored block before a collator is reported offline (0 disables)'),
+('treasury_burn_alert_threshold', '0', 'Warn ahead of a monitored treasury burn of at least this many tokens (0 disables)'),
+('treasury_burn_alert_hours', '24', 'How far ahead of the spend period to warn about a treasury burn'),
+('account_stale_hours', '48', 'Alert when an enabled account has had no successful read for this many hours (0 disables)'),
+('startup_delay_seconds', '0', 'Delay before the first balance/validator/bounty checks'),
+('startup_jitter_seconds', '0', 'Random extra delay (up to this many seconds) before each first check'),
+('startup_stagger_seconds', '0', 'Offset between the first balance, validator and bounty checks'),
+('shutdown_timeout_seconds', '10', 'Seconds a shutdown waits for the balance cycle to stop and queued alerts to be sent'),
+('announce_startup', 'false', 'Send an operational message with the running version when the monitor starts'),
+('max_cycle_duration_minutes', '120', 'Balance cycle duration budget before an operational alert is sent'),
 ('enable_notifications', 'true', 'Enable Discord notifications'),
-('min_balance_change_notification', '0.0001', 'Minimum balance change for notifications')
+('min_balance_change_notification', '0.0001', 'Minimum balance change for notifications'),
+('min_balance_change_percent', '0', 'Minimum balance change as a percentage of the previous total (0 disables)'),
+('dust_floor_plancks', '0', 'Changes smaller than this many plancks are stored but never alert'),
+('dust_floor_ed_fraction', '0', 'Native changes smaller than this fraction of the existential deposit are stored but never alert'),
+('significance_mode', 'either', 'Notify when either threshold is crossed, or only when both are'),
+('alert_mode', 'individual', 'Send each balance change as its own alert (individual) or one digest per cycle (digest)'),
+('mute_critical_alerts', 'false', 'Also silence reaping and low balance alerts for accounts muted with !mute'),
+('log_level', 'info', 'info logs cycle summaries, warnings and errors; debug adds the per-account and per-balance lines'),
+('log_sample_every', '1', 'At debug level, log the per-account lines for every Nth account only'),
+('reward_scan_max_blocks', '14400', 'Most blocks scanned per network for reward payout events each cycle (0 disables revenue tracking)'),
+('summary_min_asset_balance', '0', 'Asset holdings below this many tokens are left out of the daily summary but still stored (0 shows all)'),
+('summary_max_accounts', '0', 'Detail at most this many accounts in the daily summary; totals still cover all (0 shows all)'),
+('include_zero_balances', 'false', 'List monitored accounts and tokens in the daily summary even when their balance is zero'),
+('detect_xcm_transfers', 'true', 'Annotate matching cross-network balance moves as likely XCM transfers'),
+('summary_style', 'codeblock', 'Daily summary rendering: codeblock or embed'),
+('summary_group_by', 'account', 'Daily summary details grouped by account, token or network'),
+('discord_timeout_seconds', '10', 'Timeout for each Discord HTTP request'),
+('discord_proxy_url', '', 'Egress proxy for Discord traffic, e.g. http://proxy:3128 (empty honors HTTPS_PROXY)'),
+('max_message_length', '0', 'Override the notification backend message length limit (0 uses the backend default, 2000 for Discord)'),
+('max_concurrent_notifications', '2', 'Most notifications sent to Discord at once (0 leaves sends unbounded)'),
+('notification_template_dir', '', 'Directory of <alert>.tmpl files overriding the built-in alert messages'),
+('summary_retry_attempts', '3', 'Retries for a failed daily summary send before it is spooled to disk'),
+('summary_retry_backoff_seconds', '10', 'Initial backoff between daily summary retries, doubled each attempt'),
+('notification_retry_minutes', '15', 'Minutes between redelivery attempts for failed notifications'),
+('mute_cleanup_hours', '24', 'Hours between clearing expired account mutes (0 disables)'),
+('summary_spool_dir', 'pending_summaries', 'Directory for unsent daily summaries, resent on the next cycle'),
+('use_finalized_head', 'true', 'Read balances at the finalized head to avoid reorg-induced false alerts'),
+('low_balance_threshold', '0', 'Alert when a native balance drops below this many tokens (0 disables)'),
+('max_asset_calls_per_account', '1000', 'Per-cycle cap on asset balance RPC calls per account (0 disables)'),
+('discovery_workers', '8', 'Concurrent asset metadata fetches during network discovery'),
+('discovery_keys_page_size', '1000', 'Keys per state_getKeysPaged request when a key listing is too large for one response'),
+('api_listen_addr', '', 'Listen address for the HTTP API, e.g. :8080 (empty disables)'),
+('balance_metrics_max_series', '1000', 'Most account_balance_total series exported on /metrics; new series beyond it are dropped with a warning (0 disables)'),
+('api_history_max_points', '100', 'Maximum points returned by the balance history endpoint'),
+('auto_correct_ss58_prefix', 'false', 'Overwrite networks.ss58_prefix with the chain System.SS58Prefix constant on mismatch'),
+('auto_correct_token_properties', 'false', 'Overwrite the network and native token decimals/symbol with the chain system_properties on mismatch')
 ON DUPLICATE KEY UPDATE id=id;
 
 -- Insert default networks
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+type diffEndpoint struct {
+	Requested time.Time `json:"requested"`
+	Timestamp time.Time `json:"timestamp"`
+	Total     string    `json:"total"`
+}
+
+type tokenDiff struct {
+	Network  string       `json:"network"`
+	Token    string       `json:"token"`
+	Decimals uint8        `json:"decimals"`
+	From     diffEndpoint `json:"from"`
+	To       diffEndpoint `json:"to"`
+	Change   string       `json:"change"`
+}
+
+type diffResponse struct {
+	Address     string      `json:"address"`
+	AddressType string      `json:"address_type,omitempty"`
+	Deltas      []tokenDiff `json:"deltas"`
+}
+
+// handleDiff serves GET /accounts/{address}/diff?from=...&to=...
+// Each endpoint uses the nearest recorded balance; the timestamps actually
+// used are reported alongside the requested ones.
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+
+	from, err := parseTime(r.URL.Query().Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	to := time.Now()
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		if to, err = parseTime(toStr); err != nil {
+			writeError(w, http.StatusBadRequest, "to must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+	}
+
+	if !from.Before(to) {
+		writeError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	addressType, err := s.addressType(address)
+	if err != nil {
+		log.Printf("Failed to load address type of %s: %v", address, err)
+		writeError(w, http.StatusInternalServerError, "failed to load history")
+		return
+	}
+
+	series, err := s.db.GetHistorySeries(address)
+	if err != nil {
+		log.Printf("Failed to load history series for %s: %v", address, err)
+		writeError(w, http.StatusInternalServerError, "failed to load history")
+		return
+	}
+
+	resp := diffResponse{Address: address, AddressType: addressType, Deltas: []tokenDiff{}}
+	for _, hs := range series {
+		start, ok, err := s.db.GetNearestBalance(hs, from)
+		if err != nil {
+			log.Printf("Failed to load balance for %s on %s: %v", address, hs.Network, err)
+			writeError(w, http.StatusInternalServerError, "failed to load history")
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		end, _, err := s.db.GetNearestBalance(hs, to)
+		if err != nil {
+			log.Printf("Failed to load balance for %s on %s: %v", address, hs.Network, err)
+			writeError(w, http.StatusInternalServerError, "failed to load history")
+			return
+		}
+
+		resp.Deltas = append(resp.Deltas, tokenDiff{
+			Network:  hs.Network,
+			Token:    hs.Symbol,
+			Decimals: hs.Decimals,
+			From:     diffEndpoint{Requested: from, Timestamp: start.Time, Total: start.Total.String()},
+			To:       diffEndpoint{Requested: to, Timestamp: end.Time, Total: end.Total.String()},
+			Change:   new(big.Int).Sub(end.Total, start.Total).String(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+const (
+	defaultHistoryDays = 30
+	maxHistoryDays     = 365
+)
+
+type historyPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Total     string    `json:"total"`
+}
+
+type historyResponse struct {
+	Address     string         `json:"address"`
+	AddressType string         `json:"address_type,omitempty"`
+	Network     string         `json:"network"`
+	Token       string         `json:"token"`
+	Days        int            `json:"days"`
+	Points      []historyPoint `json:"points"`
+}
+
+// handleHistory serves GET /accounts/{address}/history?token=X&network=Y&days=N
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	token := r.URL.Query().Get("token")
+	network := r.URL.Query().Get("network")
+	if token == "" || network == "" {
+		writeError(w, http.StatusBadRequest, "token and network are required")
+		return
+	}
+
+	days := defaultHistoryDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		val, err := strconv.Atoi(daysStr)
+		if err != nil || val < 1 || val > maxHistoryDays {
+			writeError(w, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+		days = val
+	}
+
+	addressType, err := s.addressType(address)
+	if err != nil {
+		log.Printf("Failed to load address type of %s: %v", address, err)
+		writeError(w, http.StatusInternalServerError, "failed to load history")
+		return
+	}
+
+	points, err := s.db.GetBalanceHistory(address, network, token, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		log.Printf("Failed to load balance history for %s: %v", address, err)
+		writeError(w, http.StatusInternalServerError, "failed to load history")
+		return
+	}
+
+	resp := historyResponse{
+		Address:     address,
+		AddressType: addressType,
+		Network:     network,
+		Token:       token,
+		Days:        days,
+		Points:      []historyPoint{},
+	}
+	for _, p := range downsample(points, s.config.Load().APIHistoryMaxPoints) {
+		resp.Points = append(resp.Points, historyPoint{Timestamp: p.Time, Total: p.Total.String()})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// downsample reduces points to at most max by splitting them into equal
+// buckets and keeping the last point of each, so the latest total is always
+// included. max <= 0 disables downsampling.
+func downsample(points []types.BalancePoint, max int) []types.BalancePoint {
+	if max <= 0 || len(points) <= max {
+		return points
+	}
+
+	sampled := make([]types.BalancePoint, 0, max)
+	for i := 1; i <= max; i++ {
+		sampled = append(sampled, points[i*len(points)/max-1])
+	}
+	return sampled
+}
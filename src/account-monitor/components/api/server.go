@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/metrics"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/version"
+)
+
+// Server exposes read-only monitoring data over HTTP for dashboards
+type Server struct {
+	db     *database.DB
+	config *config.Live
+	srv    *http.Server
+	gauges *metrics.BalanceGauges
+}
+
+func NewServer(db *database.DB, cfg *config.Live) *Server {
+	s := &Server{
+		db:     db,
+		config: cfg,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /accounts/{address}/history", s.handleHistory)
+	mux.HandleFunc("GET /accounts/{address}/diff", s.handleDiff)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /version", s.handleVersion)
+
+	s.srv = &http.Server{
+		Addr:         cfg.Load().APIListenAddr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	return s
+}
+
+// SetBalanceGauges exposes the balance gauges on /metrics
+func (s *Server) SetBalanceGauges(gauges *metrics.BalanceGauges) {
+	s.gauges = gauges
+}
+
+// handleMetrics serves the balance gauges in the Prometheus text format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if s.gauges == nil {
+		writeError(w, http.StatusNotFound, "balance metrics are not enabled")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if err := s.gauges.WriteText(w); err != nil {
+		log.Printf("Failed to write metrics: %v", err)
+	}
+}
+
+// handleVersion serves the build information of the running binary
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, version.Get())
+}
+
+// Start serves until ctx is canceled
+func (s *Server) Start(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("API server shutdown error: %v", err)
+		}
+	}()
+
+	log.Printf("API server listening on %s", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("API server error: %v", err)
+	}
+}
+
+// addressType looks up the stored key type of address; an address that
+// isn't monitored has none and reports an empty type
+func (s *Server) addressType(address string) (string, error) {
+	addressType, err := s.db.GetAccountAddressType(address)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return addressType, err
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write API response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
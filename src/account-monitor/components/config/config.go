@@ -1,8 +1,9 @@
 package config
 
 import (
+	"log"
 	"os"
-	"strconv"
+	"sync/atomic"
 
 	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
 )
@@ -15,6 +16,7 @@ type Config struct {
 	GuildID                      string
 	AlertsChannelID              string
 	SummaryChannelID             string
+	ChangesChannelID             string
 	MonitorRoleID                string
 	CheckIntervalHours           int
 	ValidatorCheckIntervalHours  int
@@ -22,8 +24,77 @@ type Config struct {
 	EnableNotifications          bool
 	MinBalanceChangeNotification float64
 	UseDiscordBot                bool
+	DetectXcmTransfers           bool
+	MaxCycleDurationMinutes      int
+	SummaryStyle                 string
+	SummaryGroupBy               string
+	UseFinalizedHead             bool
+	LowBalanceThreshold          float64
+	AutoCorrectSS58Prefix        bool
+	AutoCorrectTokenProperties   bool
+	SummaryRetryAttempts         int
+	SummaryRetryBackoffSeconds   int
+	SummarySpoolDir              string
+	MaxAssetCallsPerAccount      int
+	APIListenAddr                string
+	APIHistoryMaxPoints          int
+	MinBalanceChangePercent      float64
+	SignificanceMode             string
+	NotificationRetryMinutes     int
+	MuteCleanupHours             int
+	NotificationTemplateDir      string
+	StartupDelaySeconds          int
+	StartupJitterSeconds         int
+	StartupStaggerSeconds        int
+	ShutdownTimeoutSeconds       int
+	AnnounceStartup              bool
+	DeltaCheckIntervalMinutes    int
+	DeltaActiveHours             int
+	NotifyNetworkChanges         bool
+	CollatorOfflineSessions      int
+	TreasuryBurnAlertThreshold   float64
+	TreasuryBurnAlertHours       int
+	AccountStaleHours            int
+	DustFloorPlancks             int
+	DustFloorEDFraction          float64
+	DiscoveryWorkers             int
+	DiscoveryKeysPageSize        int
+	AlertMode                    string
+	MaxMessageLength             int
+	MaxConcurrentNotifications   int
+	DiscordTimeoutSeconds        int
+	DiscordProxyURL              string
+	IncludeZeroBalances          bool
+	RewardScanMaxBlocks          int
+	SummaryMinAssetBalance       float64
+	SummaryMaxAccounts           int
+	BalanceMetricsMaxSeries      int
+	LogLevel                     string
+	LogSampleEvery               int
+	MuteCriticalAlerts           bool
 }
 
+// SignificanceMode values: a change is significant when it crosses either the
+// absolute or the percentage threshold, or only when it crosses both
+const (
+	SignificanceEither = "either"
+	SignificanceBoth   = "both"
+)
+
+// AlertMode values: send each balance change as its own alert, or collect
+// them into one digest per balance cycle
+const (
+	AlertModeIndividual = "individual"
+	AlertModeDigest     = "digest"
+)
+
+// LogLevel values: routine per-account lines of the balance cycle are only
+// logged at debug; cycle summaries, warnings and errors always are
+const (
+	LogLevelInfo  = "info"
+	LogLevelDebug = "debug"
+)
+
 func Load() (*Config, error) {
 	cfg := &Config{
 		MySQLDSN:                     getEnvOrDefault("MYSQL_DSN", "root:password@tcp(127.0.0.1:3306)/account_monitor?parseTime=true"),
@@ -33,6 +104,7 @@ func Load() (*Config, error) {
 		GuildID:                      os.Getenv("GUILD_ID"),
 		AlertsChannelID:              os.Getenv("ALERTS_CHANNEL_ID"),
 		SummaryChannelID:             os.Getenv("SUMMARY_CHANNEL_ID"),
+		ChangesChannelID:             os.Getenv("CHANGES_CHANNEL_ID"),
 		MonitorRoleID:                os.Getenv("MONITOR_ROLE_ID"),
 		CheckIntervalHours:           24,
 		ValidatorCheckIntervalHours:  8,
@@ -40,6 +112,33 @@ func Load() (*Config, error) {
 		EnableNotifications:          true,
 		MinBalanceChangeNotification: 0.0001,
 		UseDiscordBot:                false,
+		DetectXcmTransfers:           true,
+		MaxCycleDurationMinutes:      120,
+		SummaryStyle:                 "codeblock",
+		SummaryGroupBy:               "account",
+		MaxConcurrentNotifications:   2,
+		UseFinalizedHead:             true,
+		SummaryRetryAttempts:         3,
+		BalanceMetricsMaxSeries:      1000,
+		SummaryRetryBackoffSeconds:   10,
+		SummarySpoolDir:              "pending_summaries",
+		MaxAssetCallsPerAccount:      1000,
+		APIHistoryMaxPoints:          100,
+		SignificanceMode:             SignificanceEither,
+		NotificationRetryMinutes:     15,
+		ShutdownTimeoutSeconds:       10,
+		DeltaActiveHours:             24,
+		MuteCleanupHours:             24,
+		CollatorOfflineSessions:      2,
+		TreasuryBurnAlertHours:       24,
+		AccountStaleHours:            48,
+		DiscoveryWorkers:             8,
+		DiscoveryKeysPageSize:        1000,
+		AlertMode:                    AlertModeIndividual,
+		DiscordTimeoutSeconds:        10,
+		RewardScanMaxBlocks:          14400,
+		LogLevel:                     LogLevelInfo,
+		LogSampleEvery:               1,
 	}
 
 	// Try to load settings from database first
@@ -52,34 +151,60 @@ func Load() (*Config, error) {
 		}
 	}
 
-	// Parse interval settings from environment
-	if intervalStr := os.Getenv("CHECK_INTERVAL_HOURS"); intervalStr != "" {
-		if val, err := strconv.Atoi(intervalStr); err == nil {
-			cfg.CheckIntervalHours = val
-		}
-	}
-
-	if intervalStr := os.Getenv("VALIDATOR_CHECK_INTERVAL_HOURS"); intervalStr != "" {
-		if val, err := strconv.Atoi(intervalStr); err == nil {
-			cfg.ValidatorCheckIntervalHours = val
-		}
-	}
-
-	if intervalStr := os.Getenv("BOUNTY_CHECK_INTERVAL_MINUTES"); intervalStr != "" {
-		if val, err := strconv.Atoi(intervalStr); err == nil {
-			cfg.BountyCheckIntervalMinutes = val
-		}
-	}
-
-	if enabledStr := os.Getenv("ENABLE_NOTIFICATIONS"); enabledStr != "" {
-		cfg.EnableNotifications = enabledStr == "true" || enabledStr == "1"
-	}
-
-	if minChangeStr := os.Getenv("MIN_BALANCE_CHANGE"); minChangeStr != "" {
-		if val, err := strconv.ParseFloat(minChangeStr, 64); err == nil {
-			cfg.MinBalanceChangeNotification = val
-		}
-	}
+	// Environment overrides database settings
+	parseInt("env", "CHECK_INTERVAL_HOURS", os.Getenv("CHECK_INTERVAL_HOURS"), &cfg.CheckIntervalHours)
+	parseInt("env", "VALIDATOR_CHECK_INTERVAL_HOURS", os.Getenv("VALIDATOR_CHECK_INTERVAL_HOURS"), &cfg.ValidatorCheckIntervalHours)
+	parseInt("env", "BOUNTY_CHECK_INTERVAL_MINUTES", os.Getenv("BOUNTY_CHECK_INTERVAL_MINUTES"), &cfg.BountyCheckIntervalMinutes)
+	parseInt("env", "MAX_CYCLE_DURATION_MINUTES", os.Getenv("MAX_CYCLE_DURATION_MINUTES"), &cfg.MaxCycleDurationMinutes)
+	parseInt("env", "STARTUP_DELAY_SECONDS", os.Getenv("STARTUP_DELAY_SECONDS"), &cfg.StartupDelaySeconds)
+	parseInt("env", "STARTUP_JITTER_SECONDS", os.Getenv("STARTUP_JITTER_SECONDS"), &cfg.StartupJitterSeconds)
+	parseInt("env", "STARTUP_STAGGER_SECONDS", os.Getenv("STARTUP_STAGGER_SECONDS"), &cfg.StartupStaggerSeconds)
+	parseInt("env", "SHUTDOWN_TIMEOUT_SECONDS", os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"), &cfg.ShutdownTimeoutSeconds)
+	parseBool("env", "ANNOUNCE_STARTUP", os.Getenv("ANNOUNCE_STARTUP"), &cfg.AnnounceStartup)
+	parseInt("env", "DELTA_CHECK_INTERVAL_MINUTES", os.Getenv("DELTA_CHECK_INTERVAL_MINUTES"), &cfg.DeltaCheckIntervalMinutes)
+	parseInt("env", "DELTA_ACTIVE_HOURS", os.Getenv("DELTA_ACTIVE_HOURS"), &cfg.DeltaActiveHours)
+	parseBool("env", "NOTIFY_NETWORK_CHANGES", os.Getenv("NOTIFY_NETWORK_CHANGES"), &cfg.NotifyNetworkChanges)
+	parseInt("env", "COLLATOR_OFFLINE_SESSIONS", os.Getenv("COLLATOR_OFFLINE_SESSIONS"), &cfg.CollatorOfflineSessions)
+	parseFloat("env", "TREASURY_BURN_ALERT_THRESHOLD", os.Getenv("TREASURY_BURN_ALERT_THRESHOLD"), &cfg.TreasuryBurnAlertThreshold)
+	parseInt("env", "TREASURY_BURN_ALERT_HOURS", os.Getenv("TREASURY_BURN_ALERT_HOURS"), &cfg.TreasuryBurnAlertHours)
+	parseInt("env", "ACCOUNT_STALE_HOURS", os.Getenv("ACCOUNT_STALE_HOURS"), &cfg.AccountStaleHours)
+	parseInt("env", "DUST_FLOOR_PLANCKS", os.Getenv("DUST_FLOOR_PLANCKS"), &cfg.DustFloorPlancks)
+	parseFloat("env", "DUST_FLOOR_ED_FRACTION", os.Getenv("DUST_FLOOR_ED_FRACTION"), &cfg.DustFloorEDFraction)
+	parseInt("env", "DISCOVERY_WORKERS", os.Getenv("DISCOVERY_WORKERS"), &cfg.DiscoveryWorkers)
+	parseInt("env", "DISCOVERY_KEYS_PAGE_SIZE", os.Getenv("DISCOVERY_KEYS_PAGE_SIZE"), &cfg.DiscoveryKeysPageSize)
+	parseInt("env", "REWARD_SCAN_MAX_BLOCKS", os.Getenv("REWARD_SCAN_MAX_BLOCKS"), &cfg.RewardScanMaxBlocks)
+	parseInt("env", "MAX_MESSAGE_LENGTH", os.Getenv("MAX_MESSAGE_LENGTH"), &cfg.MaxMessageLength)
+	parseInt("env", "MAX_CONCURRENT_NOTIFICATIONS", os.Getenv("MAX_CONCURRENT_NOTIFICATIONS"), &cfg.MaxConcurrentNotifications)
+	parseInt("env", "DISCORD_TIMEOUT_SECONDS", os.Getenv("DISCORD_TIMEOUT_SECONDS"), &cfg.DiscordTimeoutSeconds)
+	parseString(os.Getenv("DISCORD_PROXY_URL"), &cfg.DiscordProxyURL)
+	parseBool("env", "ENABLE_NOTIFICATIONS", os.Getenv("ENABLE_NOTIFICATIONS"), &cfg.EnableNotifications)
+	parseFloat("env", "MIN_BALANCE_CHANGE", os.Getenv("MIN_BALANCE_CHANGE"), &cfg.MinBalanceChangeNotification)
+	parseFloat("env", "MIN_BALANCE_CHANGE_PERCENT", os.Getenv("MIN_BALANCE_CHANGE_PERCENT"), &cfg.MinBalanceChangePercent)
+	parseString(os.Getenv("SIGNIFICANCE_MODE"), &cfg.SignificanceMode)
+	parseString(os.Getenv("ALERT_MODE"), &cfg.AlertMode)
+	parseString(os.Getenv("LOG_LEVEL"), &cfg.LogLevel)
+	parseInt("env", "LOG_SAMPLE_EVERY", os.Getenv("LOG_SAMPLE_EVERY"), &cfg.LogSampleEvery)
+	parseBool("env", "DETECT_XCM_TRANSFERS", os.Getenv("DETECT_XCM_TRANSFERS"), &cfg.DetectXcmTransfers)
+	parseBool("env", "MUTE_CRITICAL_ALERTS", os.Getenv("MUTE_CRITICAL_ALERTS"), &cfg.MuteCriticalAlerts)
+	parseBool("env", "INCLUDE_ZERO_BALANCES", os.Getenv("INCLUDE_ZERO_BALANCES"), &cfg.IncludeZeroBalances)
+	parseFloat("env", "SUMMARY_MIN_ASSET_BALANCE", os.Getenv("SUMMARY_MIN_ASSET_BALANCE"), &cfg.SummaryMinAssetBalance)
+	parseInt("env", "SUMMARY_MAX_ACCOUNTS", os.Getenv("SUMMARY_MAX_ACCOUNTS"), &cfg.SummaryMaxAccounts)
+	parseInt("env", "BALANCE_METRICS_MAX_SERIES", os.Getenv("BALANCE_METRICS_MAX_SERIES"), &cfg.BalanceMetricsMaxSeries)
+	parseFloat("env", "LOW_BALANCE_THRESHOLD", os.Getenv("LOW_BALANCE_THRESHOLD"), &cfg.LowBalanceThreshold)
+	parseBool("env", "USE_FINALIZED_HEAD", os.Getenv("USE_FINALIZED_HEAD"), &cfg.UseFinalizedHead)
+	parseBool("env", "AUTO_CORRECT_SS58_PREFIX", os.Getenv("AUTO_CORRECT_SS58_PREFIX"), &cfg.AutoCorrectSS58Prefix)
+	parseBool("env", "AUTO_CORRECT_TOKEN_PROPERTIES", os.Getenv("AUTO_CORRECT_TOKEN_PROPERTIES"), &cfg.AutoCorrectTokenProperties)
+	parseString(os.Getenv("SUMMARY_STYLE"), &cfg.SummaryStyle)
+	parseString(os.Getenv("SUMMARY_GROUP_BY"), &cfg.SummaryGroupBy)
+	parseString(os.Getenv("NOTIFICATION_TEMPLATE_DIR"), &cfg.NotificationTemplateDir)
+	parseInt("env", "SUMMARY_RETRY_ATTEMPTS", os.Getenv("SUMMARY_RETRY_ATTEMPTS"), &cfg.SummaryRetryAttempts)
+	parseInt("env", "SUMMARY_RETRY_BACKOFF_SECONDS", os.Getenv("SUMMARY_RETRY_BACKOFF_SECONDS"), &cfg.SummaryRetryBackoffSeconds)
+	parseString(os.Getenv("SUMMARY_SPOOL_DIR"), &cfg.SummarySpoolDir)
+	parseInt("env", "NOTIFICATION_RETRY_MINUTES", os.Getenv("NOTIFICATION_RETRY_MINUTES"), &cfg.NotificationRetryMinutes)
+	parseInt("env", "MUTE_CLEANUP_HOURS", os.Getenv("MUTE_CLEANUP_HOURS"), &cfg.MuteCleanupHours)
+	parseInt("env", "MAX_ASSET_CALLS_PER_ACCOUNT", os.Getenv("MAX_ASSET_CALLS_PER_ACCOUNT"), &cfg.MaxAssetCallsPerAccount)
+	parseString(os.Getenv("API_LISTEN_ADDR"), &cfg.APIListenAddr)
+	parseInt("env", "API_HISTORY_MAX_POINTS", os.Getenv("API_HISTORY_MAX_POINTS"), &cfg.APIHistoryMaxPoints)
 
 	// Determine Discord mode after loading all settings
 	if cfg.DiscordToken != "" && cfg.GuildID != "" {
@@ -92,6 +217,118 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Live publishes the current Config to the goroutines reading it. A
+// published Config is never modified: Reload stores a new one, so readers
+// see either the old settings or the new ones, never a mix.
+type Live struct {
+	current atomic.Pointer[Config]
+}
+
+func NewLive(cfg *Config) *Live {
+	live := &Live{}
+	live.current.Store(cfg)
+	return live
+}
+
+// Load returns the current settings. Callers that read several settings
+// together should load once and use that Config throughout.
+func (l *Live) Load() *Config {
+	return l.current.Load()
+}
+
+// Reload re-reads the environment and settings table and publishes a copy
+// of the current Config with the settings that are safe to change at
+// runtime applied. Settings that need a reconnect (DSN, Discord credentials
+// and channels) are only reported.
+func Reload(live *Live) error {
+	fresh, err := Load()
+	if err != nil {
+		return err
+	}
+
+	next := *live.Load()
+	cfg := &next
+
+	applyRuntimeSetting("check_interval_hours", &cfg.CheckIntervalHours, fresh.CheckIntervalHours)
+	applyRuntimeSetting("validator_check_interval_hours", &cfg.ValidatorCheckIntervalHours, fresh.ValidatorCheckIntervalHours)
+	applyRuntimeSetting("bounty_check_interval_minutes", &cfg.BountyCheckIntervalMinutes, fresh.BountyCheckIntervalMinutes)
+	applyRuntimeSetting("enable_notifications", &cfg.EnableNotifications, fresh.EnableNotifications)
+	applyRuntimeSetting("min_balance_change_notification", &cfg.MinBalanceChangeNotification, fresh.MinBalanceChangeNotification)
+	applyRuntimeSetting("min_balance_change_percent", &cfg.MinBalanceChangePercent, fresh.MinBalanceChangePercent)
+	applyRuntimeSetting("significance_mode", &cfg.SignificanceMode, fresh.SignificanceMode)
+	applyRuntimeSetting("alert_mode", &cfg.AlertMode, fresh.AlertMode)
+	applyRuntimeSetting("log_level", &cfg.LogLevel, fresh.LogLevel)
+	applyRuntimeSetting("log_sample_every", &cfg.LogSampleEvery, fresh.LogSampleEvery)
+	applyRuntimeSetting("detect_xcm_transfers", &cfg.DetectXcmTransfers, fresh.DetectXcmTransfers)
+	applyRuntimeSetting("mute_critical_alerts", &cfg.MuteCriticalAlerts, fresh.MuteCriticalAlerts)
+	applyRuntimeSetting("include_zero_balances", &cfg.IncludeZeroBalances, fresh.IncludeZeroBalances)
+	applyRuntimeSetting("summary_group_by", &cfg.SummaryGroupBy, fresh.SummaryGroupBy)
+	applyRuntimeSetting("summary_min_asset_balance", &cfg.SummaryMinAssetBalance, fresh.SummaryMinAssetBalance)
+	applyRuntimeSetting("summary_max_accounts", &cfg.SummaryMaxAccounts, fresh.SummaryMaxAccounts)
+	applyRuntimeSetting("balance_metrics_max_series", &cfg.BalanceMetricsMaxSeries, fresh.BalanceMetricsMaxSeries)
+	applyRuntimeSetting("reward_scan_max_blocks", &cfg.RewardScanMaxBlocks, fresh.RewardScanMaxBlocks)
+	applyRuntimeSetting("max_cycle_duration_minutes", &cfg.MaxCycleDurationMinutes, fresh.MaxCycleDurationMinutes)
+	applyRuntimeSetting("notification_retry_minutes", &cfg.NotificationRetryMinutes, fresh.NotificationRetryMinutes)
+	applyRuntimeSetting("mute_cleanup_hours", &cfg.MuteCleanupHours, fresh.MuteCleanupHours)
+	applyRuntimeSetting("shutdown_timeout_seconds", &cfg.ShutdownTimeoutSeconds, fresh.ShutdownTimeoutSeconds)
+	applyRuntimeSetting("delta_active_hours", &cfg.DeltaActiveHours, fresh.DeltaActiveHours)
+	applyRuntimeSetting("notify_network_changes", &cfg.NotifyNetworkChanges, fresh.NotifyNetworkChanges)
+	applyRuntimeSetting("use_finalized_head", &cfg.UseFinalizedHead, fresh.UseFinalizedHead)
+	applyRuntimeSetting("low_balance_threshold", &cfg.LowBalanceThreshold, fresh.LowBalanceThreshold)
+	applyRuntimeSetting("auto_correct_ss58_prefix", &cfg.AutoCorrectSS58Prefix, fresh.AutoCorrectSS58Prefix)
+	applyRuntimeSetting("auto_correct_token_properties", &cfg.AutoCorrectTokenProperties, fresh.AutoCorrectTokenProperties)
+	applyRuntimeSetting("max_asset_calls_per_account", &cfg.MaxAssetCallsPerAccount, fresh.MaxAssetCallsPerAccount)
+	applyRuntimeSetting("api_history_max_points", &cfg.APIHistoryMaxPoints, fresh.APIHistoryMaxPoints)
+	applyRuntimeSetting("collator_offline_sessions", &cfg.CollatorOfflineSessions, fresh.CollatorOfflineSessions)
+	applyRuntimeSetting("treasury_burn_alert_threshold", &cfg.TreasuryBurnAlertThreshold, fresh.TreasuryBurnAlertThreshold)
+	applyRuntimeSetting("treasury_burn_alert_hours", &cfg.TreasuryBurnAlertHours, fresh.TreasuryBurnAlertHours)
+	applyRuntimeSetting("account_stale_hours", &cfg.AccountStaleHours, fresh.AccountStaleHours)
+	applyRuntimeSetting("dust_floor_plancks", &cfg.DustFloorPlancks, fresh.DustFloorPlancks)
+	applyRuntimeSetting("dust_floor_ed_fraction", &cfg.DustFloorEDFraction, fresh.DustFloorEDFraction)
+	applyRuntimeSetting("discovery_workers", &cfg.DiscoveryWorkers, fresh.DiscoveryWorkers)
+	applyRuntimeSetting("discovery_keys_page_size", &cfg.DiscoveryKeysPageSize, fresh.DiscoveryKeysPageSize)
+
+	restartRequired := map[string]bool{
+		"mysql_dsn":                     cfg.MySQLDSN != fresh.MySQLDSN,
+		"discord_token":                 cfg.DiscordToken != fresh.DiscordToken,
+		"discord_webhook":               cfg.DiscordWebhook != fresh.DiscordWebhook,
+		"discord_channel_id":            cfg.DiscordChannelID != fresh.DiscordChannelID,
+		"guild_id":                      cfg.GuildID != fresh.GuildID,
+		"alerts_channel_id":             cfg.AlertsChannelID != fresh.AlertsChannelID,
+		"summary_channel_id":            cfg.SummaryChannelID != fresh.SummaryChannelID,
+		"changes_channel_id":            cfg.ChangesChannelID != fresh.ChangesChannelID,
+		"monitor_role_id":               cfg.MonitorRoleID != fresh.MonitorRoleID,
+		"summary_style":                 cfg.SummaryStyle != fresh.SummaryStyle,
+		"max_message_length":            cfg.MaxMessageLength != fresh.MaxMessageLength,
+		"max_concurrent_notifications":  cfg.MaxConcurrentNotifications != fresh.MaxConcurrentNotifications,
+		"discord_timeout_seconds":       cfg.DiscordTimeoutSeconds != fresh.DiscordTimeoutSeconds,
+		"discord_proxy_url":             cfg.DiscordProxyURL != fresh.DiscordProxyURL,
+		"summary_retry_attempts":        cfg.SummaryRetryAttempts != fresh.SummaryRetryAttempts,
+		"summary_retry_backoff_seconds": cfg.SummaryRetryBackoffSeconds != fresh.SummaryRetryBackoffSeconds,
+		"summary_spool_dir":             cfg.SummarySpoolDir != fresh.SummarySpoolDir,
+		"api_listen_addr":               cfg.APIListenAddr != fresh.APIListenAddr,
+		"notification_template_dir":     cfg.NotificationTemplateDir != fresh.NotificationTemplateDir,
+		"delta_check_interval_minutes":  cfg.DeltaCheckIntervalMinutes != fresh.DeltaCheckIntervalMinutes,
+	}
+	for name, changed := range restartRequired {
+		if changed {
+			log.Printf("Setting %s changed: restart required to apply", name)
+		}
+	}
+
+	live.current.Store(cfg)
+	return nil
+}
+
+// applyRuntimeSetting updates *current to fresh, logging the change
+func applyRuntimeSetting[T comparable](name string, current *T, fresh T) {
+	if *current == fresh {
+		return
+	}
+	log.Printf("Setting %s changed: %v -> %v", name, *current, fresh)
+	*current = fresh
+}
+
 func applyDatabaseSettings(cfg *Config, settings map[string]string) {
 	if token, ok := settings["discord_token"]; ok && token != "" && cfg.DiscordToken == "" {
 		cfg.DiscordToken = token
@@ -111,32 +348,65 @@ func applyDatabaseSettings(cfg *Config, settings map[string]string) {
 	if summaryID, ok := settings["summary_channel_id"]; ok && summaryID != "" && cfg.SummaryChannelID == "" {
 		cfg.SummaryChannelID = summaryID
 	}
+	if changesID, ok := settings["changes_channel_id"]; ok && changesID != "" && cfg.ChangesChannelID == "" {
+		cfg.ChangesChannelID = changesID
+	}
 	if roleID, ok := settings["monitor_role_id"]; ok && roleID != "" && cfg.MonitorRoleID == "" {
 		cfg.MonitorRoleID = roleID
 	}
-	if interval, ok := settings["check_interval_hours"]; ok && interval != "" {
-		if val, err := strconv.Atoi(interval); err == nil {
-			cfg.CheckIntervalHours = val
-		}
-	}
-	if interval, ok := settings["validator_check_interval_hours"]; ok && interval != "" {
-		if val, err := strconv.Atoi(interval); err == nil {
-			cfg.ValidatorCheckIntervalHours = val
-		}
-	}
-	if interval, ok := settings["bounty_check_interval_minutes"]; ok && interval != "" {
-		if val, err := strconv.Atoi(interval); err == nil {
-			cfg.BountyCheckIntervalMinutes = val
-		}
-	}
-	if enabled, ok := settings["enable_notifications"]; ok && enabled != "" {
-		cfg.EnableNotifications = enabled == "true" || enabled == "1"
-	}
-	if minChange, ok := settings["min_balance_change_notification"]; ok && minChange != "" {
-		if val, err := strconv.ParseFloat(minChange, 64); err == nil {
-			cfg.MinBalanceChangeNotification = val
-		}
-	}
+	parseInt("setting", "check_interval_hours", settings["check_interval_hours"], &cfg.CheckIntervalHours)
+	parseInt("setting", "validator_check_interval_hours", settings["validator_check_interval_hours"], &cfg.ValidatorCheckIntervalHours)
+	parseInt("setting", "bounty_check_interval_minutes", settings["bounty_check_interval_minutes"], &cfg.BountyCheckIntervalMinutes)
+	parseInt("setting", "max_cycle_duration_minutes", settings["max_cycle_duration_minutes"], &cfg.MaxCycleDurationMinutes)
+	parseInt("setting", "startup_delay_seconds", settings["startup_delay_seconds"], &cfg.StartupDelaySeconds)
+	parseInt("setting", "startup_jitter_seconds", settings["startup_jitter_seconds"], &cfg.StartupJitterSeconds)
+	parseInt("setting", "startup_stagger_seconds", settings["startup_stagger_seconds"], &cfg.StartupStaggerSeconds)
+	parseInt("setting", "shutdown_timeout_seconds", settings["shutdown_timeout_seconds"], &cfg.ShutdownTimeoutSeconds)
+	parseBool("setting", "announce_startup", settings["announce_startup"], &cfg.AnnounceStartup)
+	parseInt("setting", "delta_check_interval_minutes", settings["delta_check_interval_minutes"], &cfg.DeltaCheckIntervalMinutes)
+	parseInt("setting", "delta_active_hours", settings["delta_active_hours"], &cfg.DeltaActiveHours)
+	parseBool("setting", "notify_network_changes", settings["notify_network_changes"], &cfg.NotifyNetworkChanges)
+	parseInt("setting", "collator_offline_sessions", settings["collator_offline_sessions"], &cfg.CollatorOfflineSessions)
+	parseFloat("setting", "treasury_burn_alert_threshold", settings["treasury_burn_alert_threshold"], &cfg.TreasuryBurnAlertThreshold)
+	parseInt("setting", "treasury_burn_alert_hours", settings["treasury_burn_alert_hours"], &cfg.TreasuryBurnAlertHours)
+	parseInt("setting", "account_stale_hours", settings["account_stale_hours"], &cfg.AccountStaleHours)
+	parseInt("setting", "dust_floor_plancks", settings["dust_floor_plancks"], &cfg.DustFloorPlancks)
+	parseFloat("setting", "dust_floor_ed_fraction", settings["dust_floor_ed_fraction"], &cfg.DustFloorEDFraction)
+	parseInt("setting", "discovery_workers", settings["discovery_workers"], &cfg.DiscoveryWorkers)
+	parseInt("setting", "discovery_keys_page_size", settings["discovery_keys_page_size"], &cfg.DiscoveryKeysPageSize)
+	parseInt("setting", "reward_scan_max_blocks", settings["reward_scan_max_blocks"], &cfg.RewardScanMaxBlocks)
+	parseInt("setting", "max_message_length", settings["max_message_length"], &cfg.MaxMessageLength)
+	parseInt("setting", "max_concurrent_notifications", settings["max_concurrent_notifications"], &cfg.MaxConcurrentNotifications)
+	parseInt("setting", "discord_timeout_seconds", settings["discord_timeout_seconds"], &cfg.DiscordTimeoutSeconds)
+	parseString(settings["discord_proxy_url"], &cfg.DiscordProxyURL)
+	parseBool("setting", "enable_notifications", settings["enable_notifications"], &cfg.EnableNotifications)
+	parseFloat("setting", "min_balance_change_notification", settings["min_balance_change_notification"], &cfg.MinBalanceChangeNotification)
+	parseFloat("setting", "min_balance_change_percent", settings["min_balance_change_percent"], &cfg.MinBalanceChangePercent)
+	parseString(settings["significance_mode"], &cfg.SignificanceMode)
+	parseString(settings["alert_mode"], &cfg.AlertMode)
+	parseString(settings["log_level"], &cfg.LogLevel)
+	parseInt("setting", "log_sample_every", settings["log_sample_every"], &cfg.LogSampleEvery)
+	parseBool("setting", "detect_xcm_transfers", settings["detect_xcm_transfers"], &cfg.DetectXcmTransfers)
+	parseBool("setting", "mute_critical_alerts", settings["mute_critical_alerts"], &cfg.MuteCriticalAlerts)
+	parseBool("setting", "include_zero_balances", settings["include_zero_balances"], &cfg.IncludeZeroBalances)
+	parseFloat("setting", "summary_min_asset_balance", settings["summary_min_asset_balance"], &cfg.SummaryMinAssetBalance)
+	parseInt("setting", "summary_max_accounts", settings["summary_max_accounts"], &cfg.SummaryMaxAccounts)
+	parseInt("setting", "balance_metrics_max_series", settings["balance_metrics_max_series"], &cfg.BalanceMetricsMaxSeries)
+	parseString(settings["summary_style"], &cfg.SummaryStyle)
+	parseString(settings["summary_group_by"], &cfg.SummaryGroupBy)
+	parseString(settings["notification_template_dir"], &cfg.NotificationTemplateDir)
+	parseBool("setting", "use_finalized_head", settings["use_finalized_head"], &cfg.UseFinalizedHead)
+	parseFloat("setting", "low_balance_threshold", settings["low_balance_threshold"], &cfg.LowBalanceThreshold)
+	parseInt("setting", "summary_retry_attempts", settings["summary_retry_attempts"], &cfg.SummaryRetryAttempts)
+	parseInt("setting", "summary_retry_backoff_seconds", settings["summary_retry_backoff_seconds"], &cfg.SummaryRetryBackoffSeconds)
+	parseString(settings["summary_spool_dir"], &cfg.SummarySpoolDir)
+	parseInt("setting", "notification_retry_minutes", settings["notification_retry_minutes"], &cfg.NotificationRetryMinutes)
+	parseInt("setting", "mute_cleanup_hours", settings["mute_cleanup_hours"], &cfg.MuteCleanupHours)
+	parseBool("setting", "auto_correct_ss58_prefix", settings["auto_correct_ss58_prefix"], &cfg.AutoCorrectSS58Prefix)
+	parseBool("setting", "auto_correct_token_properties", settings["auto_correct_token_properties"], &cfg.AutoCorrectTokenProperties)
+	parseInt("setting", "max_asset_calls_per_account", settings["max_asset_calls_per_account"], &cfg.MaxAssetCallsPerAccount)
+	parseString(settings["api_listen_addr"], &cfg.APIListenAddr)
+	parseInt("setting", "api_history_max_points", settings["api_history_max_points"], &cfg.APIHistoryMaxPoints)
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Setting values come from both the environment and the settings table; the
+// helpers below parse them identically and leave *dst untouched (with a
+// warning) when the value can't be parsed. Empty values are ignored.
+
+func parseBool(source, name, raw string, dst *bool) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch value {
+	case "":
+		return
+	case "true", "1", "yes", "on":
+		*dst = true
+	case "false", "0", "no", "off":
+		*dst = false
+	default:
+		log.Printf("WARNING: ignoring invalid boolean %q for %s %s", raw, source, name)
+	}
+}
+
+func parseInt(source, name, raw string, dst *int) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return
+	}
+	val, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("WARNING: ignoring invalid integer %q for %s %s", raw, source, name)
+		return
+	}
+	*dst = val
+}
+
+func parseFloat(source, name, raw string, dst *float64) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return
+	}
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("WARNING: ignoring invalid number %q for %s %s", raw, source, name)
+		return
+	}
+	*dst = val
+}
+
+func parseString(raw string, dst *string) {
+	if value := strings.TrimSpace(raw); value != "" {
+		*dst = value
+	}
+}
@@ -3,6 +3,8 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"log"
+	"math/big"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -63,7 +65,10 @@ func (db *DB) GetNetworks() ([]types.Network, error) {
 
 	rows, err := db.Query(`
 		SELECT id, name, display_name, network_type, rpc_url, ws_url, 
-		       decimals, symbol, ss58_prefix, active, last_checked_block
+		       decimals, symbol, ss58_prefix, active, last_checked_block,
+		       existential_deposit, scan_assets, scan_foreign_assets, scan_pool_assets,
+		       auth_header, tls_ca_file, tls_cert_pin, genesis_hash, evm_rpc_url,
+		       read_target, sudo_key, whale_transfer_threshold
 		FROM networks
 		WHERE active = TRUE
 	`)
@@ -76,7 +81,9 @@ func (db *DB) GetNetworks() ([]types.Network, error) {
 		var n types.Network
 		err := rows.Scan(&n.ID, &n.Name, &n.DisplayName, &n.NetworkType,
 			&n.RPCURL, &n.WSURL, &n.Decimals, &n.Symbol, &n.SS58Prefix,
-			&n.Active, &n.LastCheckedBlock)
+			&n.Active, &n.LastCheckedBlock, &n.ExistentialDeposit, &n.ScanAssets,
+			&n.ScanForeignAssets, &n.ScanPoolAssets, &n.AuthHeader, &n.TLSCAFile, &n.TLSCertPin,
+			&n.GenesisHash, &n.EVMRPCURL, &n.ReadTarget, &n.SudoKey, &n.WhaleTransferThreshold)
 		if err != nil {
 			continue
 		}
@@ -86,15 +93,92 @@ func (db *DB) GetNetworks() ([]types.Network, error) {
 	return networks, nil
 }
 
+// HasPallet reports whether discovery detected the pallet on the network
+func (db *DB) HasPallet(networkID uint, palletName string) (bool, error) {
+	var detected bool
+	err := db.QueryRow(`
+		SELECT detected FROM network_pallets
+		WHERE network_id = ? AND pallet_name = ?
+	`, networkID, palletName).Scan(&detected)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return detected, nil
+}
+
+// SetAccountMute silences the alerts of the account with the given address
+// until the given time, or unmutes it when until is NULL. It reports false
+// if no account has the address.
+func (db *DB) SetAccountMute(address string, until sql.NullTime) (bool, error) {
+	var id uint
+	err := db.QueryRow("SELECT id FROM accounts WHERE address = ?", address).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = db.Exec("UPDATE accounts SET muted_until = ? WHERE id = ?", until, id)
+	return err == nil, err
+}
+
+// SetAccountFamily sets the derivation family of the account with the
+// given address. It reports false when no such account exists.
+func (db *DB) SetAccountFamily(address, family string) (bool, error) {
+	var id uint
+	err := db.QueryRow("SELECT id FROM accounts WHERE address = ?", address).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = db.Exec("UPDATE accounts SET family = ? WHERE id = ?", family, id)
+	return err == nil, err
+}
+
+// ClearExpiredMutes resets muted_until on accounts whose mute has ended,
+// returning how many were cleared
+func (db *DB) ClearExpiredMutes() (int64, error) {
+	result, err := db.Exec("UPDATE accounts SET muted_until = NULL WHERE muted_until <= NOW()")
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetAccountMute returns when the account's mute ends; it is NULL when the
+// account isn't muted
+func (db *DB) GetAccountMute(accountID uint) (sql.NullTime, error) {
+	var until sql.NullTime
+	err := db.QueryRow("SELECT muted_until FROM accounts WHERE id = ?", accountID).Scan(&until)
+	return until, err
+}
+
+// GetAccountAddressType returns the key type of the account with this
+// address, or sql.ErrNoRows if it isn't in the accounts table
+func (db *DB) GetAccountAddressType(address string) (string, error) {
+	var addressType sql.NullString
+	err := db.QueryRow("SELECT address_type FROM accounts WHERE address = ?", address).Scan(&addressType)
+	return addressType.String, err
+}
+
 // GetAccounts retrieves all monitored accounts
 func (db *DB) GetAccounts() ([]types.Account, error) {
 	var accounts []types.Account
 
 	rows, err := db.Query(`
 		SELECT id, address, address_type, name, description, 
-		       monitor_enabled, discord_notify
+		       monitor_enabled, discord_notify, notify_mask, profile, family, last_checked, created_at
 		FROM accounts
 		WHERE monitor_enabled = TRUE
+		ORDER BY id
 	`)
 	if err != nil {
 		return nil, err
@@ -104,10 +188,15 @@ func (db *DB) GetAccounts() ([]types.Account, error) {
 	for rows.Next() {
 		var a types.Account
 		err := rows.Scan(&a.ID, &a.Address, &a.AddressType, &a.Name,
-			&a.Description, &a.MonitorEnabled, &a.DiscordNotify)
+			&a.Description, &a.MonitorEnabled, &a.DiscordNotify, &a.NotifyMask, &a.Profile, &a.Family, &a.LastChecked, &a.CreatedAt)
 		if err != nil {
 			continue
 		}
+		if !types.ValidAddressType(a.AddressType) {
+			log.Printf("WARNING: account %s has unknown address_type %q, treating as %s",
+				a.Address, a.AddressType, types.AddressTypeSubstrate)
+			a.AddressType = types.AddressTypeSubstrate
+		}
 		accounts = append(accounts, a)
 	}
 
@@ -118,18 +207,17 @@ func (db *DB) GetAccounts() ([]types.Account, error) {
 func (db *DB) UpdateBalance(accountID, networkID, tokenID uint, balance types.Balance) error {
 	_, err := db.Exec(`
 		INSERT INTO balances (account_id, network_id, network_token_id, free, reserved, 
-		                     misc_frozen, fee_frozen, bonded, total)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                     frozen, bonded, total)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON DUPLICATE KEY UPDATE
 		free = VALUES(free),
 		reserved = VALUES(reserved),
-		misc_frozen = VALUES(misc_frozen),
-		fee_frozen = VALUES(fee_frozen),
+		frozen = VALUES(frozen),
 		bonded = VALUES(bonded),
 		total = VALUES(total),
 		last_updated = CURRENT_TIMESTAMP
 	`, accountID, networkID, tokenID, balance.Free.String(), balance.Reserved.String(),
-		balance.MiscFrozen.String(), balance.FeeFrozen.String(), balance.Bonded.String(), balance.Total.String())
+		balance.Frozen.String(), balance.Bonded.String(), balance.Total.String())
 
 	return err
 }
@@ -147,3 +235,327 @@ func (db *DB) RecordBalanceChange(change types.BalanceChange) error {
 
 	return err
 }
+
+// UpsertAccount inserts an account or updates the name, description and tags
+// of an existing one. It reports whether a new row was created.
+func (db *DB) UpsertAccount(address, addressType, name, description, tags string) (bool, error) {
+	result, err := db.Exec(`
+		INSERT INTO accounts (address, address_type, name, description, tags)
+		VALUES (?, ?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''))
+		ON DUPLICATE KEY UPDATE
+		address_type = VALUES(address_type),
+		name = VALUES(name),
+		description = VALUES(description),
+		tags = VALUES(tags)
+	`, address, addressType, name, description, tags)
+	if err != nil {
+		return false, err
+	}
+
+	// MySQL reports 1 affected row for an insert and 2 for an update
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// GetBalanceHistory returns the recorded totals for an account on a network
+// and token since the given time, oldest first
+func (db *DB) GetBalanceHistory(address, network, symbol string, since time.Time) ([]types.BalancePoint, error) {
+	rows, err := db.Query(`
+		SELECT bh.recorded_at, bh.total_after
+		FROM balance_history bh
+		JOIN accounts a ON a.id = bh.account_id
+		JOIN networks n ON n.id = bh.network_id
+		JOIN network_tokens nt ON nt.id = bh.network_token_id
+		WHERE a.address = ? AND n.name = ? AND nt.symbol = ? AND bh.recorded_at >= ?
+		ORDER BY bh.recorded_at, bh.id
+	`, address, network, symbol, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []types.BalancePoint
+	for rows.Next() {
+		var p types.BalancePoint
+		var total string
+		if err := rows.Scan(&p.Time, &total); err != nil {
+			return nil, err
+		}
+		value, ok := new(big.Int).SetString(total, 10)
+		if !ok {
+			continue
+		}
+		p.Total = value
+		points = append(points, p)
+	}
+
+	return points, rows.Err()
+}
+
+// UpsertBounty stores a bounty's on-chain state and returns its row id
+func (db *DB) UpsertBounty(b types.Bounty) (uint, error) {
+	result, err := db.Exec(`
+		INSERT INTO bounties (network_id, bounty_id, proposer, curator, fee, curator_deposit,
+		                      bond, value, status, beneficiary, update_due, unlock_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULLIF(?, 0), NULLIF(?, 0))
+		ON DUPLICATE KEY UPDATE
+		id = LAST_INSERT_ID(id),
+		proposer = VALUES(proposer),
+		curator = VALUES(curator),
+		fee = VALUES(fee),
+		curator_deposit = VALUES(curator_deposit),
+		bond = VALUES(bond),
+		value = VALUES(value),
+		status = VALUES(status),
+		beneficiary = VALUES(beneficiary),
+		update_due = VALUES(update_due),
+		unlock_at = VALUES(unlock_at)
+	`, b.NetworkID, b.BountyID, b.Proposer, b.Curator, b.Fee.String(), b.CuratorDeposit.String(),
+		b.Bond.String(), b.Value.String(), b.Status, b.Beneficiary, b.UpdateDue, b.UnlockAt)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+// AddFailedNotification persists a notification that could not be delivered
+func (db *DB) AddFailedNotification(notificationType, target, content, reason string) error {
+	_, err := db.Exec(`
+		INSERT INTO failed_notifications (notification_type, target, content, failure_reason)
+		VALUES (?, ?, ?, ?)
+	`, notificationType, target, content, reason)
+	return err
+}
+
+// GetFailedNotifications returns undelivered notifications, oldest first
+func (db *DB) GetFailedNotifications() ([]types.FailedNotification, error) {
+	rows, err := db.Query(`
+		SELECT id, notification_type, target, content, failure_reason, attempts, created_at, last_attempt_at
+		FROM failed_notifications
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []types.FailedNotification
+	for rows.Next() {
+		var n types.FailedNotification
+		if err := rows.Scan(&n.ID, &n.Type, &n.Target, &n.Content, &n.FailureReason,
+			&n.Attempts, &n.CreatedAt, &n.LastAttemptAt); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// MarkNotificationAttempt records another failed delivery attempt
+func (db *DB) MarkNotificationAttempt(id uint64, reason string) error {
+	_, err := db.Exec(`
+		UPDATE failed_notifications
+		SET attempts = attempts + 1, failure_reason = ?, last_attempt_at = NOW()
+		WHERE id = ?
+	`, reason, id)
+	return err
+}
+
+// DeleteFailedNotification removes a notification once it has been delivered
+func (db *DB) DeleteFailedNotification(id uint64) error {
+	_, err := db.Exec("DELETE FROM failed_notifications WHERE id = ?", id)
+	return err
+}
+
+// GetHistorySeries lists the network/token pairs with recorded history for
+// an account
+func (db *DB) GetHistorySeries(address string) ([]types.HistorySeries, error) {
+	rows, err := db.Query(`
+		SELECT DISTINCT bh.account_id, n.id, n.name, nt.id, nt.symbol, nt.decimals
+		FROM balance_history bh
+		JOIN accounts a ON a.id = bh.account_id
+		JOIN networks n ON n.id = bh.network_id
+		JOIN network_tokens nt ON nt.id = bh.network_token_id
+		WHERE a.address = ?
+		ORDER BY n.name, nt.symbol
+	`, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series []types.HistorySeries
+	for rows.Next() {
+		var s types.HistorySeries
+		if err := rows.Scan(&s.AccountID, &s.NetworkID, &s.Network, &s.TokenID, &s.Symbol, &s.Decimals); err != nil {
+			return nil, err
+		}
+		series = append(series, s)
+	}
+
+	return series, rows.Err()
+}
+
+// GetNearestBalance returns the latest recorded total at or before at,
+// falling back to the earliest record after it. ok is false when the series
+// has no records.
+func (db *DB) GetNearestBalance(s types.HistorySeries, at time.Time) (point types.BalancePoint, ok bool, err error) {
+	queries := []string{`
+		SELECT recorded_at, total_after FROM balance_history
+		WHERE account_id = ? AND network_id = ? AND network_token_id = ? AND recorded_at <= ?
+		ORDER BY recorded_at DESC, id DESC LIMIT 1
+	`, `
+		SELECT recorded_at, total_after FROM balance_history
+		WHERE account_id = ? AND network_id = ? AND network_token_id = ? AND recorded_at > ?
+		ORDER BY recorded_at, id LIMIT 1
+	`}
+
+	for _, query := range queries {
+		var total string
+		err = db.QueryRow(query, s.AccountID, s.NetworkID, s.TokenID, at).Scan(&point.Time, &total)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return point, false, err
+		}
+		value, valid := new(big.Int).SetString(total, 10)
+		if !valid {
+			return point, false, fmt.Errorf("invalid total %q in balance history", total)
+		}
+		point.Total = value
+		return point, true, nil
+	}
+
+	return point, false, nil
+}
+
+// MarkAccountChecked records a successful read for the account without
+// touching updated_at
+func (db *DB) MarkAccountChecked(accountID uint) error {
+	_, err := db.Exec(`
+		UPDATE accounts SET last_checked = NOW(), updated_at = updated_at WHERE id = ?
+	`, accountID)
+	return err
+}
+
+// GetChangedAccounts returns the ids of the accounts with a recorded
+// balance change since the given time
+func (db *DB) GetChangedAccounts(since time.Time) (map[uint]bool, error) {
+	rows, err := db.Query(`
+		SELECT DISTINCT account_id FROM balance_history
+		WHERE recorded_at >= ? AND change_type <> 'no_change'
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changed := make(map[uint]bool)
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		changed[id] = true
+	}
+	return changed, rows.Err()
+}
+
+// GetCycleProgress returns the unfinished balance cycle, if any, with the
+// "account_id:network_id" pairs it already processed. cycleID is zero when
+// no cycle is in progress.
+func (db *DB) GetCycleProgress() (cycleID int64, done map[string]bool, err error) {
+	var latest sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(cycle_id) FROM cycle_progress").Scan(&latest); err != nil {
+		return 0, nil, err
+	}
+	if !latest.Valid {
+		return 0, nil, nil
+	}
+
+	rows, err := db.Query(`
+		SELECT account_id, network_id FROM cycle_progress WHERE cycle_id = ?
+	`, latest.Int64)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	done = make(map[string]bool)
+	for rows.Next() {
+		var accountID, networkID uint
+		if err := rows.Scan(&accountID, &networkID); err != nil {
+			return 0, nil, err
+		}
+		done[fmt.Sprintf("%d:%d", accountID, networkID)] = true
+	}
+
+	return latest.Int64, done, rows.Err()
+}
+
+// GetAccountNetworks returns the networks each account is restricted to, as
+// account id -> network id set. Accounts without rows are absent and are
+// checked on every network.
+func (db *DB) GetAccountNetworks() (map[uint]map[uint]bool, error) {
+	rows, err := db.Query("SELECT account_id, network_id FROM account_networks")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	restricted := make(map[uint]map[uint]bool)
+	for rows.Next() {
+		var accountID, networkID uint
+		if err := rows.Scan(&accountID, &networkID); err != nil {
+			return nil, err
+		}
+		if restricted[accountID] == nil {
+			restricted[accountID] = make(map[uint]bool)
+		}
+		restricted[accountID][networkID] = true
+	}
+
+	return restricted, rows.Err()
+}
+
+// MarkCycleProgress records that a cycle processed an account on a network
+func (db *DB) MarkCycleProgress(cycleID int64, accountID, networkID uint) error {
+	_, err := db.Exec(`
+		INSERT IGNORE INTO cycle_progress (cycle_id, account_id, network_id) VALUES (?, ?, ?)
+	`, cycleID, accountID, networkID)
+	return err
+}
+
+// ClearCycleProgress forgets a completed cycle along with any older leftovers
+func (db *DB) ClearCycleProgress(cycleID int64) error {
+	_, err := db.Exec("DELETE FROM cycle_progress WHERE cycle_id <= ?", cycleID)
+	return err
+}
+
+// SetLastCheckedBlock records the last block whose events were scanned
+func (db *DB) SetLastCheckedBlock(networkID uint, block uint64) error {
+	_, err := db.Exec("UPDATE networks SET last_checked_block = ? WHERE id = ?", block, networkID)
+	return err
+}
+
+// SetGenesisHash records a network's genesis hash the first time it is seen
+func (db *DB) SetGenesisHash(networkID uint, hash string) error {
+	_, err := db.Exec("UPDATE networks SET genesis_hash = ? WHERE id = ? AND genesis_hash IS NULL", hash, networkID)
+	return err
+}
+
+// SetSudoKey records the network's Sudo.Key account, or "" once removed
+func (db *DB) SetSudoKey(networkID uint, key string) error {
+	_, err := db.Exec("UPDATE networks SET sudo_key = ? WHERE id = ?", key, networkID)
+	return err
+}
@@ -0,0 +1,49 @@
+package discord
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+)
+
+// SetChangesChannel sets the channel for the compact change feed; empty
+// disables it. The feed posts to a channel id, so it needs the bot client.
+func (c *Client) SetChangesChannel(channelID string) {
+	if c == nil || channelID == "" {
+		return
+	}
+	if !c.isBot {
+		log.Printf("WARNING: changes_channel_id is set but the webhook client can't post to other channels; the change feed is disabled")
+		return
+	}
+	c.changesID = channelID
+	log.Printf("Balance changes will also be posted to channel: %s", channelID)
+}
+
+// SendChangeLine posts a balance change to the changes channel as a single
+// plain line, e.g. "DOT polkadot 5Grw…utQY +123.4567"
+func (c *Client) SendChangeLine(account, network, token string, change *big.Int, decimals uint8) error {
+	if c == nil || c.changesID == "" || c.session == nil {
+		return nil
+	}
+
+	defer c.acquireSend()()
+	_, err := c.session.ChannelMessageSend(c.changesID, changeLine(account, network, token, change, decimals))
+	return err
+}
+
+func changeLine(account, network, token string, change *big.Int, decimals uint8) string {
+	amount := formatTokenAmountSimple(change, decimals)
+	if change != nil && change.Sign() > 0 {
+		amount = "+" + amount
+	}
+	return fmt.Sprintf("%s %s %s %s", token, network, compactAddress(account), amount)
+}
+
+// compactAddress keeps the first and last four characters of an address
+func compactAddress(address string) string {
+	if len(address) <= 12 {
+		return address
+	}
+	return address[:4] + "…" + address[len(address)-4:]
+}
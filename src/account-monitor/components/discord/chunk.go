@@ -0,0 +1,79 @@
+package discord
+
+import "strings"
+
+// Discord rejects messages longer than this many characters
+const discordMessageLimit = 2000
+
+const codeFence = "```"
+
+// SetMessageLimit overrides the backend's maximum message length, for relays
+// that accept a different limit than Discord
+func (c *Client) SetMessageLimit(limit int) {
+	if c == nil || limit <= 0 {
+		return
+	}
+	c.messageLimit = limit
+}
+
+// chunkLines joins lines into messages of at most limit characters, starting
+// each message with header. Lines are never split; an oversized line is
+// truncated.
+func chunkLines(header string, lines []string, limit int) []string {
+	var messages []string
+	var msg strings.Builder
+	msg.WriteString(header)
+
+	for _, line := range lines {
+		line = truncate(line, limit-len(header)-1)
+		if msg.Len()+1+len(line) > limit {
+			messages = append(messages, msg.String())
+			msg.Reset()
+			msg.WriteString(header)
+		}
+		msg.WriteString("\n")
+		msg.WriteString(line)
+	}
+
+	return append(messages, msg.String())
+}
+
+// splitMessage splits content on line boundaries into messages of at most
+// limit characters. A code block cut by a split is closed at the end of one
+// message and reopened at the start of the next.
+func splitMessage(content string, limit int) []string {
+	if len(content) <= limit {
+		return []string{content}
+	}
+
+	var messages []string
+	var msg strings.Builder
+	inCode := false
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		line = truncate(line, limit-2*len(codeFence)-2)
+		after := inCode != (strings.Count(line, codeFence)%2 == 1)
+
+		// Leave room to close a code block still open after this line
+		reserve := 0
+		if after {
+			reserve = len(codeFence)
+		}
+		if msg.Len() > 0 && msg.Len()+len(line)+reserve > limit {
+			out := msg.String()
+			if inCode {
+				out = strings.TrimSuffix(out, "\n") + "\n" + codeFence
+			}
+			messages = append(messages, out)
+			msg.Reset()
+			if inCode {
+				msg.WriteString(codeFence + "\n")
+			}
+		}
+
+		msg.WriteString(line)
+		inCode = after
+	}
+
+	return append(messages, msg.String())
+}
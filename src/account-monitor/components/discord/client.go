@@ -1,16 +1,17 @@
 package discord
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
 	"net/http"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
 )
 
 type Client struct {
@@ -20,7 +21,28 @@ type Client struct {
 	session    *discordgo.Session
 	alertsID   string
 	summaryID  string
+	changesID  string
 	isBot      bool
+
+	summaryStyle string
+
+	// Daily summary delivery: retries with exponential backoff, then spool
+	// unsent parts to disk for the next cycle
+	summaryRetries int
+	summaryBackoff time.Duration
+	spoolDir       string
+
+	// Alerts that fail to send are persisted here for later redelivery
+	deadLetters *database.DB
+
+	// Operator overrides of the built-in alert templates
+	templates map[string]*template.Template
+
+	// Longest message the backend accepts; longer content is chunked
+	messageLimit int
+
+	// Bounds concurrent sends when set; see SetMaxConcurrentSends
+	sendSlots chan struct{}
 }
 
 type Embed struct {
@@ -47,23 +69,37 @@ type WebhookMessage struct {
 	Embeds  []Embed `json:"embeds,omitempty"`
 }
 
-func NewWebhookClient(webhookURL, channelID string) *Client {
-	return &Client{
-		webhookURL: webhookURL,
-		channelID:  channelID,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		isBot: false,
+func NewWebhookClient(webhookURL, channelID string, opts HTTPOptions) (*Client, error) {
+	httpClient, err := opts.httpClient()
+	if err != nil {
+		return nil, err
 	}
+
+	return &Client{
+		webhookURL:   webhookURL,
+		channelID:    channelID,
+		httpClient:   httpClient,
+		isBot:        false,
+		messageLimit: discordMessageLimit,
+	}, nil
 }
 
-func NewBotClient(token, alertsChannelID, summaryChannelID string) (*Client, error) {
+func NewBotClient(token, alertsChannelID, summaryChannelID string, opts HTTPOptions) (*Client, error) {
 	session, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
 
+	// REST calls and the gateway websocket both go through the proxy
+	session.Client, err = opts.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	proxy, _ := opts.proxy()
+	dialer := *session.Dialer
+	dialer.Proxy = proxy
+	session.Dialer = &dialer
+
 	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages
 
 	if err := session.Open(); err != nil {
@@ -71,10 +107,11 @@ func NewBotClient(token, alertsChannelID, summaryChannelID string) (*Client, err
 	}
 
 	return &Client{
-		session:   session,
-		alertsID:  alertsChannelID,
-		summaryID: summaryChannelID,
-		isBot:     true,
+		session:      session,
+		alertsID:     alertsChannelID,
+		summaryID:    summaryChannelID,
+		isBot:        true,
+		messageLimit: discordMessageLimit,
 	}, nil
 }
 
@@ -90,29 +127,179 @@ func (c *Client) SendBalanceChangeNotification(account, network, token string, b
 
 	change := new(big.Int).Sub(after, before)
 
-	msg := fmt.Sprintf("**%s Balance Change Alert**\n", emoji)
-	msg += fmt.Sprintf("Account: `%s`\n", formatAddress(account))
-	msg += fmt.Sprintf("Network: %s | Token: %s\n", network, token)
-	msg += fmt.Sprintf("Change: %s\n", formatBalance(change, token))
-	msg += fmt.Sprintf("Before: %s → After: %s",
-		formatBalance(before, token), formatBalance(after, token))
+	return c.sendTemplatedAlert(templateBalanceChange, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Token:   token,
+		Emoji:   emoji,
+		Change:  formatBalance(change, token),
+		Before:  formatBalance(before, token),
+		After:   formatBalance(after, token),
+	})
+}
+
+func (c *Client) SendLowBalanceAlert(account, network, token string, balance, threshold *big.Int, decimals uint8) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateLowBalance, AlertData{
+		Account:   formatAddress(account),
+		Network:   network,
+		Token:     token,
+		Amount:    formatTokenAmountSimple(balance, decimals),
+		Threshold: formatTokenAmountSimple(threshold, decimals),
+	})
+}
+
+// SendAccountFundedAlert reports the first funds seen on an account for a
+// token, e.g. a newly provisioned account receiving its initial transfer
+func (c *Client) SendAccountFundedAlert(account, network, token string, amount *big.Int, decimals uint8) error {
+	if c == nil {
+		return nil
+	}
 
-	return c.sendMessage(msg, true)
+	return c.sendTemplatedAlert(templateFunded, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Token:   token,
+		After:   formatTokenAmountSimple(amount, decimals),
+	})
 }
 
-func (c *Client) SendChildBountyAlert(account, network string, bountyID, childBountyID uint64, amount *big.Int, token string) error {
+// SendReservedClearedAlert reports an account's reserved balance dropping
+// to zero. When the total fell by about as much, the reserve was most likely
+// slashed; otherwise it was released to the free balance.
+func (c *Client) SendReservedClearedAlert(account, network, token string, reserved, totalChange *big.Int, decimals uint8) error {
 	if c == nil {
 		return nil
 	}
 
-	msg := fmt.Sprintf("**🎁 Child Bounty Ready to Claim!**\n")
-	msg += fmt.Sprintf("Beneficiary: `%s`\n", formatAddress(account))
-	msg += fmt.Sprintf("Network: %s | Token: %s\n", network, token)
-	msg += fmt.Sprintf("Parent Bounty: #%d | Child Bounty: #%d\n", bountyID, childBountyID)
-	msg += fmt.Sprintf("Amount: %s\n", formatBalance(amount, token))
-	msg += fmt.Sprintf("Status: ✅ Ready to claim")
+	message := "The reserve was released to the free balance, e.g. an identity, proxy, asset approval or deposit was removed."
+	if new(big.Int).Neg(totalChange).Cmp(new(big.Int).Div(reserved, big.NewInt(2))) >= 0 {
+		message = "The total balance fell with it: the reserve was likely slashed or forfeited."
+	}
+
+	return c.sendTemplatedAlert(templateReserved, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Token:   token,
+		Before:  formatTokenAmountSimple(reserved, decimals),
+		After:   "0",
+		Change:  formatTokenAmountSimple(totalChange, decimals),
+		Message: message,
+	})
+}
+
+func (c *Client) SendReapedAlert(account, network, token string, before *big.Int, decimals uint8) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateReaped, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Token:   token,
+		Before:  formatTokenAmountSimple(before, decimals),
+	})
+}
+
+func (c *Client) SendChildBountyAlert(account, network string, bountyID, childBountyID uint64, amount *big.Int,
+	token string, decimals uint8, curator, parentCurator string) error {
+	if c == nil {
+		return nil
+	}
+
+	data := AlertData{
+		Account:       formatAddress(account),
+		Network:       network,
+		Token:         token,
+		BountyID:      bountyID,
+		ChildBountyID: childBountyID,
+		Amount:        formatTokenAmountSimple(amount, decimals),
+	}
+	if curator != "" {
+		data.Curator = formatAddress(curator)
+	}
+	if parentCurator != "" {
+		data.ParentCurator = formatAddress(parentCurator)
+	}
+
+	return c.sendTemplatedAlert(templateChildBounty, data)
+}
+
+// ChildBountyUpdate describes a child bounty lifecycle step ahead of the
+// payout becoming claimable
+type ChildBountyUpdate struct {
+	BountyID      uint64
+	ChildBountyID uint64
+	Amount        *big.Int
+	Token         string
+	Decimals      uint8
+	Curator       string
+	ParentCurator string
+	Emoji         string
+	Title         string
+	Message       string
+}
+
+// SendChildBountyStatusAlert tells a curator or beneficiary that a child
+// bounty reached a state where they should act or prepare
+func (c *Client) SendChildBountyStatusAlert(account, network string, update ChildBountyUpdate) error {
+	if c == nil {
+		return nil
+	}
+
+	data := AlertData{
+		Account:       formatAddress(account),
+		Network:       network,
+		Token:         update.Token,
+		BountyID:      update.BountyID,
+		ChildBountyID: update.ChildBountyID,
+		Amount:        formatTokenAmountSimple(update.Amount, update.Decimals),
+		Emoji:         update.Emoji,
+		Type:          "bounty",
+		Title:         update.Title,
+		Message:       update.Message,
+	}
+	if update.Curator != "" {
+		data.Curator = formatAddress(update.Curator)
+	}
+	if update.ParentCurator != "" {
+		data.ParentCurator = formatAddress(update.ParentCurator)
+	}
+
+	return c.sendTemplatedAlert(templateChildStatus, data)
+}
+
+// sendTemplatedAlert renders an alert template and sends the result
+func (c *Client) sendTemplatedAlert(name string, data AlertData) error {
+	msg, err := c.renderAlert(name, data)
+	if err != nil {
+		return err
+	}
+	return c.sendAlert(name, msg)
+}
+
+// SetSummaryStyle selects how daily summaries are rendered: as a monospaced
+// code block (default) or as embeds with one field per account
+func (c *Client) SetSummaryStyle(style string) {
+	if c == nil {
+		return
+	}
+	c.summaryStyle = style
+}
 
-	return c.sendMessage(msg, true)
+// SetSummaryDelivery configures how many times a daily summary send is
+// retried, the initial backoff between attempts, and where unsent summaries
+// are spooled for the next cycle. An empty spoolDir disables spooling.
+func (c *Client) SetSummaryDelivery(retries int, backoff time.Duration, spoolDir string) {
+	if c == nil {
+		return
+	}
+	c.summaryRetries = retries
+	c.summaryBackoff = backoff
+	c.spoolDir = spoolDir
 }
 
 func (c *Client) SendDailySummary(summary DailySummary) error {
@@ -120,11 +307,31 @@ func (c *Client) SendDailySummary(summary DailySummary) error {
 		return nil
 	}
 
+	var parts []summaryPart
+	if c.summaryStyle == SummaryStyleEmbed {
+		for _, embed := range buildSummaryEmbeds(summary) {
+			embed := embed
+			parts = append(parts, summaryPart{Embed: &embed})
+		}
+	} else {
+		for _, content := range splitMessage(renderSummaryText(summary), c.messageLimit) {
+			parts = append(parts, summaryPart{Content: content})
+		}
+	}
+
+	return c.deliverSummary(parts)
+}
+
+// renderSummaryText renders a DailySummary as a code block message
+func renderSummaryText(summary DailySummary) string {
 	var msg strings.Builder
 	msg.WriteString(fmt.Sprintf("**📊 Daily Portfolio Summary - %s**\n", time.Now().Format("2006-01-02")))
 	msg.WriteString("```\n")
 	msg.WriteString(fmt.Sprintf("Active Accounts: %d | Active Networks: %d\n",
 		summary.TotalAccounts, summary.ActiveNetworks))
+	if len(summary.UnavailableNetworks) > 0 {
+		msg.WriteString(unavailableNetworksLine(summary.UnavailableNetworks) + "\n")
+	}
 	msg.WriteString("─────────────────────────────────────────\n")
 
 	// Portfolio totals by token
@@ -145,19 +352,25 @@ func (c *Client) SendDailySummary(summary DailySummary) error {
 		msg.WriteString("─────────────────────────────────────────\n")
 	}
 
-	// Account details
-	if len(summary.AccountSummaries) > 0 {
+	// Account details, or the token or network view of them
+	if len(summary.AccountSummaries) > 0 && pivoted(summary) {
+		renderGroupedText(&msg, summary)
+	} else if len(summary.AccountSummaries) > 0 {
 		msg.WriteString("ACCOUNT DETAILS\n\n")
 		for _, account := range summary.AccountSummaries {
-			msg.WriteString(fmt.Sprintf("%s (%s)\n", account.Name, formatAddress(account.Address)))
+			msg.WriteString(fmt.Sprintf("%s (%s)%s%s\n", account.Name, formatAddress(account.Address),
+				addressTypeLabel(account.AddressType), mutedLabel(account.Muted)))
 
 			// Group balances by token
 			tokenGroups := make(map[string][]*TokenBalance)
 			for _, tb := range account.TokenBalances {
-				if tb.Balance != nil && tb.Balance.Cmp(big.NewInt(0)) > 0 {
-					tokenGroups[tb.Symbol] = append(tokenGroups[tb.Symbol], tb)
+				if tb.Balance != nil && (tb.Balance.Cmp(big.NewInt(0)) > 0 || summary.IncludeZeroBalances) {
+					tokenGroups[tb.TokenKey()] = append(tokenGroups[tb.TokenKey()], tb)
 				}
 			}
+			if summary.IncludeZeroBalances && allZero(account) {
+				msg.WriteString("  ∅ All balances zero (checked)\n")
+			}
 
 			// Display each token with its networks
 			for symbol, balances := range tokenGroups {
@@ -173,27 +386,105 @@ func (c *Client) SendDailySummary(summary DailySummary) error {
 				totalStr := formatTokenAmountSimple(total, decimals)
 				changeStr := formatTokenAmountSimple(change, decimals)
 
-				msg.WriteString(fmt.Sprintf("  %-8s Total: %12s  Change: %12s\n",
-					symbol+":", totalStr, changeStr))
+				msg.WriteString(fmt.Sprintf("  %-8s Total: %12s  Change: %12s%s\n",
+					symbol+":", totalStr, changeStr, lpLabel(balances[0].TokenType)))
 
 				// Show network breakdown
 				for _, bal := range balances {
 					balStr := formatTokenAmountSimple(bal.Balance, bal.Decimals)
 					msg.WriteString(fmt.Sprintf("    %-20s %12s", bal.Network+":", balStr))
+					if bal.Balance.Sign() == 0 {
+						msg.WriteString(" (zero)")
+					}
 					if bal.Change != nil && bal.Change.Cmp(big.NewInt(0)) != 0 {
 						changeStr := formatTokenAmountSimple(bal.Change, bal.Decimals)
 						msg.WriteString(fmt.Sprintf(" (%s)", changeStr))
 					}
+					if bal.RewardDestination != "" || len(bal.BondedBySource) > 0 {
+						msg.WriteString(fmt.Sprintf(" [bonded %s%s", formatTokenAmountSimple(bal.Bonded, bal.Decimals),
+							bondedSourcesLine(bal.BondedBySource, bal.Decimals)))
+						if bal.RewardDestination != "" {
+							msg.WriteString(fmt.Sprintf(", rewards: %s", bal.RewardDestination))
+						}
+						msg.WriteString("]")
+					}
+					// A lock that only covers the bonded amount is already shown
+					if bal.Locked != nil && bal.Locked.Sign() > 0 && (bal.Bonded == nil || bal.Locked.Cmp(bal.Bonded) != 0) {
+						msg.WriteString(fmt.Sprintf(" 🔒 locked %s", formatTokenAmountSimple(bal.Locked, bal.Decimals)))
+					}
+					if bal.Frozen != "" {
+						msg.WriteString(fmt.Sprintf(" ❄ frozen (%s), spendable %s", bal.Frozen,
+							formatTokenAmountSimple(bal.Spendable, bal.Decimals)))
+					}
 					msg.WriteString("\n")
+					for _, a := range bal.Approvals {
+						msg.WriteString(fmt.Sprintf("      ✍ approved %s to %s (deposit %s %s)\n",
+							formatTokenAmountSimple(a.Amount, bal.Decimals), formatAddress(a.Delegate),
+							formatTokenAmountSimple(a.Deposit, a.DepositDecimals), a.DepositSymbol))
+					}
 				}
 			}
+
+			// Cross-network moves that look like XCM transfers
+			for _, t := range account.Transfers {
+				msg.WriteString(fmt.Sprintf("  ⇄ %s %s: %s → %s (likely XCM transfer)\n",
+					formatTokenAmountSimple(t.Sent, t.Decimals), t.Symbol, t.FromNetwork, t.ToNetwork))
+			}
+
+			if len(account.TruncatedScans) > 0 {
+				msg.WriteString(fmt.Sprintf("  ⚠ Asset scan truncated on %s, balances may be incomplete\n",
+					strings.Join(account.TruncatedScans, ", ")))
+			}
 			msg.WriteString("\n")
 		}
+		if summary.OmittedAccounts > 0 {
+			msg.WriteString(omittedAccountsLine(summary.OmittedAccounts) + "\n\n")
+		}
+	}
+
+	// Rewards paid out since the previous summary
+	if len(summary.Revenue) > 0 {
+		msg.WriteString("─────────────────────────────────────────\n")
+		msg.WriteString("REVENUE SINCE LAST SUMMARY\n\n")
+		for _, r := range summary.Revenue {
+			msg.WriteString(fmt.Sprintf("%-10s  %s\n", r.Symbol, revenueLine(r)))
+		}
+	}
+
+	// Validator pending reward estimates
+	if len(summary.ValidatorEstimates) > 0 {
+		msg.WriteString("─────────────────────────────────────────\n")
+		msg.WriteString("VALIDATORS\n\n")
+		for _, v := range summary.ValidatorEstimates {
+			msg.WriteString(fmt.Sprintf("%s (%s) on %s\n", v.Name, formatAddress(v.Address), v.Network))
+			msg.WriteString(fmt.Sprintf("  Era %d: %d of %d points, estimated pending ~%s %s (estimate)\n",
+				v.Era, v.Points, v.TotalPoints, formatTokenAmountSimple(v.Estimate, v.Decimals), v.Symbol))
+		}
+	}
+
+	// Monitoring gaps
+	if len(summary.StaleAccounts) > 0 {
+		msg.WriteString("─────────────────────────────────────────\n")
+		msg.WriteString("NETWORK STATUS\n\n")
+		for _, a := range summary.StaleAccounts {
+			msg.WriteString(fmt.Sprintf("⚠ Stale: %s\n", staleLine(a)))
+		}
+	}
+
+	// Treasury pots and projected burns
+	if len(summary.Treasuries) > 0 {
+		msg.WriteString("─────────────────────────────────────────\n")
+		msg.WriteString("TREASURIES\n\n")
+		for _, t := range summary.Treasuries {
+			msg.WriteString(fmt.Sprintf("%s (%s) on %s\n", t.Name, formatAddress(t.Address), t.Network))
+			msg.WriteString(fmt.Sprintf("  Projected burn ~%s %s; %s\n",
+				formatTokenAmountSimple(t.ProjectedBurn, t.Decimals), t.Symbol, treasuryBurnLine(t)))
+		}
 	}
 
 	msg.WriteString("```")
 
-	return c.sendMessage(msg.String(), false)
+	return msg.String()
 }
 
 func (c *Client) SendValidatorAlert(address, network string, alert ValidatorAlert) error {
@@ -209,28 +500,266 @@ func (c *Client) SendValidatorAlert(address, network string, alert ValidatorAler
 		icon = "🚨"
 	}
 
-	msg := fmt.Sprintf("**%s Validator Alert: %s**\n", icon, alert.Type)
-	msg += fmt.Sprintf("Validator: `%s`\n", formatAddress(address))
-	msg += fmt.Sprintf("Network: %s\n", network)
-	msg += fmt.Sprintf("%s\n", alert.Message)
-
-	if len(alert.UnclaimedEras) > 0 {
-		msg += fmt.Sprintf("Unclaimed Eras: %v\n", alert.UnclaimedEras)
+	data := AlertData{
+		Account:       formatAddress(address),
+		Network:       network,
+		Emoji:         icon,
+		Type:          alert.Type,
+		Message:       alert.Message,
+		UnclaimedEras: alert.UnclaimedEras,
 	}
 	if alert.UnclaimedAmount != nil {
-		msg += fmt.Sprintf("Claimable: %s\n", formatBalance(alert.UnclaimedAmount, ""))
+		data.Claimable = formatBalance(alert.UnclaimedAmount, "")
 	}
 	if alert.ExpiredAmount != nil {
-		msg += fmt.Sprintf("Expired: %s\n", formatBalance(alert.ExpiredAmount, ""))
+		data.Expired = formatBalance(alert.ExpiredAmount, "")
+	}
+
+	return c.sendTemplatedAlert(templateValidator, data)
+}
+
+// SendRoleChangeAlert reports an account starting or stopping a staking role
+func (c *Client) SendRoleChangeAlert(account, network, role string, started bool) error {
+	if c == nil {
+		return nil
+	}
+
+	data := AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Type:    role,
+		Emoji:   "🟢",
+		Title:   "started " + role,
+		Message: fmt.Sprintf("Account is now a %s", role),
+	}
+	if !started {
+		data.Emoji = "🔴"
+		data.Title = "stopped " + role
+		data.Message = fmt.Sprintf("Account is no longer a %s", role)
+	}
+
+	return c.sendTemplatedAlert(templateRoleChange, data)
+}
+
+// SendCollatorAlert reports a collator liveness problem, or its recovery
+// when resolved is set
+func (c *Client) SendCollatorAlert(account, network, title, message string, resolved bool) error {
+	if c == nil {
+		return nil
+	}
+
+	data := AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Type:    "collator",
+		Emoji:   "🚨",
+		Title:   title,
+		Message: message,
+	}
+	if resolved {
+		data.Emoji = "✅"
+	}
+
+	return c.sendTemplatedAlert(templateCollator, data)
+}
+
+// SendTreasuryBurnAlert warns that a large burn is due at the next spend
+// period
+func (c *Client) SendTreasuryBurnAlert(treasury TreasurySummary) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateTreasuryBurn, AlertData{
+		Account: formatAddress(treasury.Address),
+		Network: treasury.Network,
+		Token:   treasury.Symbol,
+		Amount:  formatTokenAmountSimple(treasury.ProjectedBurn, treasury.Decimals),
+		Emoji:   "🔥",
+		Type:    "treasury_burn",
+		Message: treasuryBurnLine(treasury),
+	})
+}
+
+// treasuryBurnLine describes the pot and when the burn applies
+func treasuryBurnLine(t TreasurySummary) string {
+	return fmt.Sprintf("Pot %s %s, burn %.2f%% at block %d (~%s)",
+		formatTokenAmountSimple(t.Pot, t.Decimals), t.Symbol, float64(t.BurnPermill)/10000,
+		t.NextSpendBlock, t.NextSpendAt.UTC().Format("2006-01-02 15:04 MST"))
+}
+
+// SendAccountStateAlert reports an unusual on-chain account state
+func (c *Client) SendAccountStateAlert(account, network, title, message string) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateAccountState, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Emoji:   "⚠️",
+		Type:    "account_state",
+		Title:   title,
+		Message: message,
+	})
+}
+
+// SendProxyAnnouncementAlert reports a call a proxy delegate announced for
+// the account, which it can execute from executableAt
+func (c *Client) SendProxyAnnouncementAlert(account, network, delegate, callHash string, executableAt, current uint32) error {
+	if c == nil {
+		return nil
+	}
+
+	when := "now"
+	if executableAt > current {
+		when = fmt.Sprintf("in %d blocks", executableAt-current)
+	}
+
+	return c.sendTemplatedAlert(templateProxyAnnounce, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Emoji:   "🕵️",
+		Type:    "proxy",
+		Title:   "proxy announcement",
+		Message: fmt.Sprintf("Delegate `%s` announced call `%s`, executable at block %d (%s)",
+			delegate, callHash, executableAt, when),
+	})
+}
+
+// SendScheduledTaskAlert reports a Scheduler task referencing the account,
+// dispatched at block (estimated at when). origin is set when the call
+// dispatches as the account.
+func (c *Client) SendScheduledTaskAlert(account, network, call string, block uint32, when time.Time, origin, periodic bool) error {
+	if c == nil {
+		return nil
+	}
+
+	role := "references this account"
+	if origin {
+		role = "dispatches as this account"
+	}
+	repeat := ""
+	if periodic {
+		repeat = ", repeating"
 	}
 
-	return c.sendMessage(msg, true)
+	return c.sendTemplatedAlert(templateScheduled, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Emoji:   "⏰",
+		Type:    "scheduled",
+		Title:   "scheduled call",
+		Message: fmt.Sprintf("Scheduled call `%s` %s, enacted at block %d (~%s%s)",
+			call, role, block, when.UTC().Format("2006-01-02 15:04 MST"), repeat),
+	})
+}
+
+// SendAssetApprovalAlert reports an asset approval the account granted, or
+// raised, letting delegate transfer up to amount of its balance. An
+// approval covering the whole balance is called out as large.
+func (c *Client) SendAssetApprovalAlert(account, network, token, delegate string, amount, balance, deposit *big.Int,
+	decimals uint8, depositToken string, depositDecimals uint8) error {
+	if c == nil {
+		return nil
+	}
+
+	title := "new approval"
+	if balance != nil && amount.Cmp(balance) >= 0 {
+		title = "large approval"
+	}
+	return c.sendTemplatedAlert(templateApproval, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Token:   token,
+		Amount:  formatTokenAmountSimple(amount, decimals),
+		Emoji:   "✍️",
+		Type:    "asset_approval",
+		Title:   title,
+		Message: fmt.Sprintf("`%s` can now transfer up to this amount out of the account (balance %s %s, deposit %s %s reserved)",
+			delegate, formatTokenAmountSimple(balance, decimals), token,
+			formatTokenAmountSimple(deposit, depositDecimals), depositToken),
+	})
+}
+
+// SendLargeTransferAlert reports a native transfer of at least the
+// network's whale threshold, whoever sent it
+func (c *Client) SendLargeTransferAlert(network, token, from, to string, amount, threshold *big.Int, decimals uint8, block uint64) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateWhale, AlertData{
+		Account:   formatAddress(from),
+		Network:   network,
+		Token:     token,
+		Amount:    formatTokenAmountSimple(amount, decimals),
+		Threshold: formatTokenAmountSimple(threshold, decimals),
+		Emoji:     "🐋",
+		Type:      "whale",
+		Title:     "large transfer",
+		Message: fmt.Sprintf("`%s` sent it to `%s` at block %d (threshold %s %s)",
+			from, to, block, formatTokenAmountSimple(threshold, decimals), token),
+	})
+}
+
+// SendAssetTrapAlert reports assets trapped by a failed XCM execution from
+// the account, recoverable with claim_assets
+func (c *Client) SendAssetTrapAlert(account, network, hash string, assets int, block uint64) error {
+	if c == nil {
+		return nil
+	}
+
+	count := fmt.Sprintf("%d assets", assets)
+	if assets == 1 {
+		count = "1 asset"
+	}
+	return c.sendTemplatedAlert(templateAssetTrap, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Emoji:   "🪤",
+		Type:    "asset_trap",
+		Title:   "XCM assets trapped",
+		Message: fmt.Sprintf("%s trapped at block %d (trap `%s`); recover them with `claim_assets`",
+			count, block, hash),
+	})
+}
+
+// SendIdentityAlert reports a change to an account's on-chain identity or
+// its registrar judgements
+func (c *Client) SendIdentityAlert(account, network, title, message string) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateIdentity, AlertData{
+		Account: formatAddress(account),
+		Network: network,
+		Emoji:   "🪪",
+		Type:    "identity",
+		Title:   title,
+		Message: message,
+	})
+}
+
+// SendOperationalAlert reports a problem with the monitor itself rather than
+// a monitored account
+func (c *Client) SendOperationalAlert(title, message string) error {
+	if c == nil {
+		return nil
+	}
+
+	return c.sendTemplatedAlert(templateOperational, AlertData{
+		Title:   title,
+		Message: message,
+	})
 }
 
 func (c *Client) sendMessage(content string, isAlert bool) error {
 	if c == nil {
 		return nil
 	}
+	defer c.acquireSend()()
 
 	if c.isBot {
 		return c.sendBotMessage(content, isAlert)
@@ -266,27 +795,9 @@ func (c *Client) sendWebhookMessage(content string) error {
 		return nil
 	}
 
-	msg := map[string]string{
+	return c.postWebhook(map[string]string{
 		"content": content,
-	}
-
-	jsonData, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
-	}
-
-	resp, err := c.httpClient.Post(c.webhookURL, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		log.Printf("Failed to send Discord webhook: %v", err)
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
-	}
-
-	return nil
+	})
 }
 
 func (c *Client) Close() error {
@@ -321,14 +832,25 @@ func formatBalance(amount *big.Int, token string) string {
 	return formatted
 }
 
+// FormatTokenAmount formats a planck amount with the token's decimals the
+// same way alerts do
+func FormatTokenAmount(amount *big.Int, decimals uint8) string {
+	return formatTokenAmountSimple(amount, decimals)
+}
+
 // Simple string-based formatting that works
 func formatTokenAmountSimple(amount *big.Int, decimals uint8) string {
 	if amount == nil || amount.Cmp(big.NewInt(0)) == 0 {
 		return "0.0000"
 	}
 
-	// Convert amount to string
-	amountStr := amount.String()
+	// Format the magnitude; the sign is restored at the end so small
+	// negative changes don't end up with the minus inside the digits
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	amountStr := new(big.Int).Abs(amount).String()
 
 	// Calculate where to place decimal
 	decimalPos := len(amountStr) - int(decimals)
@@ -356,7 +878,24 @@ func formatTokenAmountSimple(amount *big.Int, decimals uint8) string {
 		}
 	}
 
-	return result
+	return sign + result
+}
+
+// addressTypeLabel returns a " [type]" suffix for accounts with an explicit
+// crypto scheme; the legacy "substrate" default is left unlabeled
+func addressTypeLabel(addressType string) string {
+	if addressType == "" || addressType == "substrate" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", addressType)
+}
+
+// mutedLabel marks an account whose alerts are silenced in the summary
+func mutedLabel(muted bool) string {
+	if !muted {
+		return ""
+	}
+	return " 🔇 muted"
 }
 
 func formatAddress(address string) string {
@@ -367,12 +906,105 @@ func formatAddress(address string) string {
 }
 
 type TokenBalance struct {
+	// Key identifies the token for aggregation; it equals Symbol for native
+	// tokens and carries a network/asset qualifier for assets
+	Key       string
 	Network   string
 	Balance   *big.Int
 	Symbol    string
 	Decimals  uint8
 	Change    *big.Int
 	TokenType string
+	// Bonded, BondedBySource and RewardDestination are only set for native
+	// staking balances
+	Bonded            *big.Int
+	BondedBySource    map[string]*big.Int
+	RewardDestination string
+	// Spendable is the free balance not held by freezes and locks; Frozen
+	// says why a native balance can't be moved, making Spendable zero
+	Spendable *big.Int
+	Frozen    string
+	// Locked is the part of the free balance held by locks and freezes
+	// (staking, vesting, governance), for native balances
+	Locked *big.Int
+	// Approvals are the allowances granted on an Assets pallet asset
+	Approvals []Approval
+}
+
+// Approval is an outstanding asset allowance in the account details. Its
+// deposit is reserved from the native balance, so it carries the native
+// token's symbol and decimals.
+type Approval struct {
+	Delegate        string
+	Amount          *big.Int
+	Deposit         *big.Int
+	DepositSymbol   string
+	DepositDecimals uint8
+}
+
+// bondedSourcesLine lists the bonded amount per staking pallet when it
+// comes from more than one, e.g. " (Staking 10.0000, NominationPools 5.0000)"
+func bondedSourcesLine(bySource map[string]*big.Int, decimals uint8) string {
+	if len(bySource) < 2 {
+		return ""
+	}
+
+	pallets := make([]string, 0, len(bySource))
+	for pallet := range bySource {
+		pallets = append(pallets, pallet)
+	}
+	sort.Strings(pallets)
+
+	parts := make([]string, len(pallets))
+	for i, pallet := range pallets {
+		parts[i] = fmt.Sprintf("%s %s", pallet, formatTokenAmountSimple(bySource[pallet], decimals))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// allZero reports whether every checked balance of the account is zero
+func allZero(account AccountSummary) bool {
+	for _, tb := range account.TokenBalances {
+		if tb.Balance != nil && tb.Balance.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// omittedAccountsLine notes the accounts the summary cap left out
+func omittedAccountsLine(count int) string {
+	if count == 1 {
+		return "...and 1 more account"
+	}
+	return fmt.Sprintf("...and %d more accounts", count)
+}
+
+// unavailableNetworksLine warns that the totals leave out networks that
+// couldn't be read, so a missing chain isn't mistaken for a balance drop
+func unavailableNetworksLine(names []string) string {
+	noun := "networks"
+	if len(names) == 1 {
+		noun = "network"
+	}
+	return fmt.Sprintf("⚠ Note: data for %s %s unavailable this cycle, totals are incomplete",
+		strings.Join(names, ", "), noun)
+}
+
+// lpLabel marks liquidity pool (PoolAssets) tokens in the summary
+func lpLabel(tokenType string) string {
+	if tokenType == "pool_asset" {
+		return " (LP position)"
+	}
+	return ""
+}
+
+// TokenKey returns the aggregation key, falling back to the symbol
+func (tb *TokenBalance) TokenKey() string {
+	if tb.Key != "" {
+		return tb.Key
+	}
+	return tb.Symbol
 }
 
 type TokenTotal struct {
@@ -388,20 +1020,130 @@ type DailySummary struct {
 	TotalChanges       int
 	TotalsByToken      map[string]*TokenTotal
 	TokenDecimals      map[string]uint8
-	ChildBountyRevenue *big.Int
-	ValidatorRevenue   *big.Int
-	CollatorRevenue    *big.Int
-	StakingRevenue     *big.Int
+	Revenue            []RevenueTotal
 	AccountSummaries   []AccountSummary
+	ValidatorEstimates []ValidatorEstimate
+	Treasuries         []TreasurySummary
+	StaleAccounts      []StaleAccount
+	// IncludeZeroBalances lists checked zero balances instead of hiding them
+	IncludeZeroBalances bool
+	// OmittedAccounts counts accounts left out of AccountSummaries by the
+	// summary_max_accounts cap
+	OmittedAccounts int
+	// GroupBy pivots the account details by SummaryGroupToken or
+	// SummaryGroupNetwork; empty or SummaryGroupAccount keeps them per
+	// account
+	GroupBy string
+	// UnavailableNetworks lists networks whose endpoint failed during the
+	// cycle; their balances are missing from the totals
+	UnavailableNetworks []string
+}
+
+// RevenueTotal is what monitored accounts earned in one token since the
+// previous summary, read from payout events
+type RevenueTotal struct {
+	Symbol      string
+	Decimals    uint8
+	Validator   *big.Int
+	Collator    *big.Int
+	Staking     *big.Int
+	ChildBounty *big.Int
+}
+
+// revenueLine lists the non-zero revenue sources of a token
+func revenueLine(r RevenueTotal) string {
+	sources := []struct {
+		name   string
+		amount *big.Int
+	}{
+		{"validator", r.Validator},
+		{"collator", r.Collator},
+		{"staking", r.Staking},
+		{"child bounties", r.ChildBounty},
+	}
+
+	var parts []string
+	for _, source := range sources {
+		if source.amount != nil && source.amount.Sign() != 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", source.name, formatTokenAmountSimple(source.amount, r.Decimals)))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// StaleAccount is an enabled account with no successful read within the
+// staleness window; LastChecked is zero if it was never read
+type StaleAccount struct {
+	Name        string
+	Address     string
+	LastChecked time.Time
+}
+
+// staleLine describes when a stale account was last read
+func staleLine(a StaleAccount) string {
+	if a.LastChecked.IsZero() {
+		return fmt.Sprintf("%s (%s): never checked", a.Name, formatAddress(a.Address))
+	}
+	return fmt.Sprintf("%s (%s): last checked %s", a.Name, formatAddress(a.Address),
+		a.LastChecked.UTC().Format("2006-01-02 15:04 MST"))
+}
+
+// TreasurySummary is a monitored treasury's pot and the burn projected for
+// its next spend period
+type TreasurySummary struct {
+	Name           string
+	Address        string
+	Network        string
+	Symbol         string
+	Decimals       uint8
+	Pot            *big.Int
+	ProjectedBurn  *big.Int
+	BurnPermill    uint32
+	NextSpendBlock uint32
+	NextSpendAt    time.Time
+}
+
+// ValidatorEstimate is a projection of a validator's reward for the active
+// era from its reward points so far; it is an estimate, not a payout
+type ValidatorEstimate struct {
+	Name        string
+	Address     string
+	Network     string
+	Symbol      string
+	Decimals    uint8
+	Era         uint32
+	Points      uint32
+	TotalPoints uint32
+	Estimate    *big.Int
 }
 
 type AccountSummary struct {
 	Name           string
 	Address        string
+	AddressType    string
 	Summary        string
 	TokenBalances  []*TokenBalance
 	TotalsByToken  map[string]*big.Int
 	ChangesByToken map[string]*big.Int
+	Transfers      []CrossChainTransfer
+	// TruncatedScans lists networks where the asset scan hit the per-account cap
+	TruncatedScans []string
+	// Muted is set while the account's alerts are silenced with !mute
+	Muted bool
+}
+
+// CrossChainTransfer is a decrease on one network matched with an increase
+// of the same token on another within a single cycle
+type CrossChainTransfer struct {
+	Symbol      string
+	Decimals    uint8
+	FromNetwork string
+	ToNetwork   string
+	Sent        *big.Int
+	Received    *big.Int
 }
 
 type ValidatorAlert struct {
@@ -0,0 +1,207 @@
+package discord
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+)
+
+// EnableMuteCommands starts handling "!mute <address> <duration>" and
+// "!unmute <address>" from members with the given role. Reading commands
+// needs the privileged message content intent; if the bot isn't allowed it
+// the connection is restored and /mute and /unmute slash commands, which
+// don't need the intent, are registered in the guild instead.
+func (c *Client) EnableMuteCommands(db *database.DB, guildID, roleID string) {
+	if c == nil || !c.isBot || c.session == nil {
+		return
+	}
+	if roleID == "" {
+		log.Printf("monitor_role_id is not set; !mute and !unmute are disabled")
+		return
+	}
+
+	intents := c.session.Identify.Intents
+	c.session.Close()
+	c.session.Identify.Intents |= discordgo.IntentsMessageContent
+	if err := c.session.Open(); err != nil {
+		log.Printf("WARNING: the Discord bot was refused the Message Content intent (%v). "+
+			"Enable \"Message Content Intent\" under Bot > Privileged Gateway Intents in the Discord developer portal "+
+			"to use !mute and !unmute; falling back to the /mute and /unmute slash commands", err)
+		c.session.Identify.Intents = intents
+		if err := c.session.Open(); err != nil {
+			log.Printf("Failed to reopen Discord connection: %v", err)
+			return
+		}
+		c.enableMuteSlashCommands(db, guildID, roleID)
+		return
+	}
+
+	c.session.AddHandler(func(s *discordgo.Session, msg *discordgo.MessageCreate) {
+		c.handleMuteCommand(s, msg, db, roleID)
+	})
+	log.Printf("Discord commands !mute and !unmute enabled for role %s", roleID)
+}
+
+// enableMuteSlashCommands registers /mute and /unmute in the guild and
+// handles them from members with the given role
+func (c *Client) enableMuteSlashCommands(db *database.DB, guildID, roleID string) {
+	if guildID == "" || c.session.State == nil || c.session.State.User == nil {
+		log.Printf("WARNING: /mute and /unmute need guild_id; muting from Discord is disabled")
+		return
+	}
+
+	address := &discordgo.ApplicationCommandOption{
+		Type:        discordgo.ApplicationCommandOptionString,
+		Name:        "address",
+		Description: "Monitored account address",
+		Required:    true,
+	}
+	commands := []*discordgo.ApplicationCommand{
+		{
+			Name:        "mute",
+			Description: "Silence an account's balance change alerts",
+			Options: []*discordgo.ApplicationCommandOption{address, {
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "duration",
+				Description: "How long, e.g. 30m, 12h, 2d or 1w",
+				Required:    true,
+			}},
+		},
+		{
+			Name:        "unmute",
+			Description: "Restore an account's alerts",
+			Options:     []*discordgo.ApplicationCommandOption{address},
+		},
+	}
+	for _, command := range commands {
+		if _, err := c.session.ApplicationCommandCreate(c.session.State.User.ID, guildID, command); err != nil {
+			log.Printf("Failed to register /%s: %v", command.Name, err)
+			return
+		}
+	}
+
+	c.session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		c.handleMuteInteraction(s, i, db, roleID)
+	})
+	log.Printf("Discord commands /mute and /unmute enabled for role %s", roleID)
+}
+
+func (c *Client) handleMuteCommand(s *discordgo.Session, msg *discordgo.MessageCreate, db *database.DB, roleID string) {
+	if msg.Author == nil || msg.Author.Bot || msg.Member == nil {
+		return
+	}
+	args := strings.Fields(msg.Content)
+	if len(args) == 0 || (args[0] != "!mute" && args[0] != "!unmute") {
+		return
+	}
+
+	reply := func(text string) {
+		if _, err := s.ChannelMessageSend(msg.ChannelID, text); err != nil {
+			log.Printf("Failed to reply to %s: %v", args[0], err)
+		}
+	}
+
+	if !slices.Contains(msg.Member.Roles, roleID) {
+		reply(fmt.Sprintf("%s is restricted to the monitor role.", args[0]))
+		return
+	}
+
+	switch {
+	case args[0] == "!mute" && len(args) == 3:
+		reply(setMute(db, msg.Author.Username, args[1], args[2]))
+	case args[0] == "!unmute" && len(args) == 2:
+		reply(setMute(db, msg.Author.Username, args[1], ""))
+	default:
+		reply("Usage: `!mute <address> <duration>` or `!unmute <address>`")
+	}
+}
+
+func (c *Client) handleMuteInteraction(s *discordgo.Session, i *discordgo.InteractionCreate, db *database.DB, roleID string) {
+	if i.Type != discordgo.InteractionApplicationCommand || i.Member == nil || i.Member.User == nil {
+		return
+	}
+	data := i.ApplicationCommandData()
+	if data.Name != "mute" && data.Name != "unmute" {
+		return
+	}
+
+	var text string
+	if !slices.Contains(i.Member.Roles, roleID) {
+		text = fmt.Sprintf("/%s is restricted to the monitor role.", data.Name)
+	} else {
+		options := make(map[string]string)
+		for _, option := range data.Options {
+			options[option.Name] = option.StringValue()
+		}
+		text = setMute(db, i.Member.User.Username, options["address"], options["duration"])
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: text},
+	}); err != nil {
+		log.Printf("Failed to reply to /%s: %v", data.Name, err)
+	}
+}
+
+// setMute mutes the account for duration, or unmutes it when duration is
+// empty, and returns the reply for the member who asked
+func setMute(db *database.DB, user, address, duration string) string {
+	var until sql.NullTime
+	if duration != "" {
+		d, err := parseMuteDuration(duration)
+		if err != nil {
+			return fmt.Sprintf("Invalid duration %q: use e.g. 30m, 12h, 2d or 1w.", duration)
+		}
+		until = sql.NullTime{Time: time.Now().Add(d), Valid: true}
+	}
+
+	found, err := db.SetAccountMute(address, until)
+	if err != nil {
+		log.Printf("Failed to update mute of %s: %v", address, err)
+		return fmt.Sprintf("Failed to update %s, see the monitor log.", formatAddress(address))
+	}
+	if !found {
+		return fmt.Sprintf("No monitored account %s.", address)
+	}
+
+	if until.Valid {
+		log.Printf("%s muted %s until %s", user, address, until.Time.Format(time.RFC3339))
+		return fmt.Sprintf("🔇 Alerts for %s muted until <t:%d:f>. Reaping and low balance alerts still fire unless mute_critical_alerts is set.",
+			formatAddress(address), until.Time.Unix())
+	}
+	log.Printf("%s unmuted %s", user, address)
+	return fmt.Sprintf("🔔 Alerts for %s unmuted.", formatAddress(address))
+}
+
+// parseMuteDuration accepts Go durations (30m, 12h) plus days and weeks
+// (2d, 1w)
+func parseMuteDuration(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(s, "d"), strings.HasSuffix(s, "w"):
+		n, convErr := strconv.Atoi(s[:len(s)-1])
+		err = convErr
+		d = time.Duration(n) * 24 * time.Hour
+		if strings.HasSuffix(s, "w") {
+			d *= 7
+		}
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
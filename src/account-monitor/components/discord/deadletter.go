@@ -0,0 +1,75 @@
+package discord
+
+import (
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+)
+
+// SetDeadLetterStore enables persisting alerts that fail to send to the
+// failed_notifications table
+func (c *Client) SetDeadLetterStore(db *database.DB) {
+	if c == nil {
+		return
+	}
+	c.deadLetters = db
+}
+
+// sendAlert sends an alert, persisting it for redelivery if the send fails
+func (c *Client) sendAlert(kind, content string) error {
+	err := c.sendMessage(content, true)
+	if err == nil || c.deadLetters == nil {
+		return err
+	}
+
+	if dlqErr := c.deadLetters.AddFailedNotification(kind, c.alertTarget(), content, err.Error()); dlqErr != nil {
+		log.Printf("Failed to persist undelivered %s notification: %v", kind, dlqErr)
+	}
+	return err
+}
+
+// alertTarget describes where alerts are delivered, for inspection
+func (c *Client) alertTarget() string {
+	if !c.isBot {
+		return "webhook"
+	}
+	if c.alertsID != "" {
+		return c.alertsID
+	}
+	return c.summaryID
+}
+
+// RetryFailedNotifications re-attempts delivery of persisted alerts, oldest
+// first, removing those that succeed. It stops at the first failure since
+// Discord is most likely still unreachable.
+func (c *Client) RetryFailedNotifications() error {
+	if c == nil || c.deadLetters == nil {
+		return nil
+	}
+
+	pending, err := c.deadLetters.GetFailedNotifications()
+	if err != nil {
+		return err
+	}
+
+	delivered := 0
+	for _, n := range pending {
+		if err := c.sendMessage(n.Content, true); err != nil {
+			if markErr := c.deadLetters.MarkNotificationAttempt(n.ID, err.Error()); markErr != nil {
+				log.Printf("Failed to update failed notification %d: %v", n.ID, markErr)
+			}
+			log.Printf("Redelivered %d/%d failed notifications, still failing: %v", delivered, len(pending), err)
+			return nil
+		}
+
+		if err := c.deadLetters.DeleteFailedNotification(n.ID); err != nil {
+			return err
+		}
+		delivered++
+	}
+
+	if delivered > 0 {
+		log.Printf("Redelivered %d failed notifications", delivered)
+	}
+	return nil
+}
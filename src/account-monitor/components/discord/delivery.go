@@ -0,0 +1,130 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// summaryPart is one Discord message of a daily summary, either plain
+// content or a single embed
+type summaryPart struct {
+	Content string `json:"content,omitempty"`
+	Embed   *Embed `json:"embed,omitempty"`
+}
+
+func (c *Client) sendSummaryPart(part summaryPart) error {
+	if part.Embed != nil {
+		return c.sendEmbed(*part.Embed, false)
+	}
+	return c.sendMessage(part.Content, false)
+}
+
+// sendWithRetry sends a part, retrying with exponential backoff
+func (c *Client) sendWithRetry(part summaryPart) error {
+	backoff := c.summaryBackoff
+	var err error
+	for attempt := 0; attempt <= c.summaryRetries; attempt++ {
+		if attempt > 0 {
+			log.Printf("Retrying daily summary send in %s (attempt %d/%d): %v",
+				backoff, attempt, c.summaryRetries, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = c.sendSummaryPart(part); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// sendParts sends parts in order, returning the index of the first part that
+// could not be delivered (len(parts) on success)
+func (c *Client) sendParts(parts []summaryPart) (int, error) {
+	for i, part := range parts {
+		if err := c.sendWithRetry(part); err != nil {
+			return i, err
+		}
+	}
+	return len(parts), nil
+}
+
+// deliverSummary sends a summary, spooling any parts that still fail after
+// all retries so they can be resent on the next cycle
+func (c *Client) deliverSummary(parts []summaryPart) error {
+	sent, err := c.sendParts(parts)
+	if err == nil {
+		return nil
+	}
+
+	if c.spoolDir == "" {
+		return err
+	}
+
+	path, spoolErr := c.spoolSummary(parts[sent:])
+	if spoolErr != nil {
+		return fmt.Errorf("%w (failed to spool summary: %v)", err, spoolErr)
+	}
+	return fmt.Errorf("%w (summary spooled to %s)", err, path)
+}
+
+func (c *Client) spoolSummary(parts []summaryPart) (string, error) {
+	if err := os.MkdirAll(c.spoolDir, 0o755); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(parts)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(c.spoolDir, fmt.Sprintf("summary-%s.json", time.Now().Format("20060102-150405")))
+	return path, os.WriteFile(path, data, 0o644)
+}
+
+// ResendPendingSummaries delivers summaries spooled by earlier failed sends,
+// oldest first. It stops at the first failure and keeps the unsent parts.
+func (c *Client) ResendPendingSummaries() error {
+	if c == nil || c.spoolDir == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.spoolDir, "summary-*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var parts []summaryPart
+		if err := json.Unmarshal(data, &parts); err != nil {
+			log.Printf("Discarding unreadable pending summary %s: %v", path, err)
+			os.Remove(path)
+			continue
+		}
+
+		log.Printf("Resending pending summary %s", path)
+		sent, sendErr := c.sendParts(parts)
+		if sendErr != nil {
+			if remaining, err := json.Marshal(parts[sent:]); err == nil {
+				os.WriteFile(path, remaining, 0o644)
+			}
+			return fmt.Errorf("failed to resend %s: %w", path, sendErr)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
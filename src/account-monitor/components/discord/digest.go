@@ -0,0 +1,50 @@
+package discord
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// DigestEntry is one significant balance change collected for a digest
+type DigestEntry struct {
+	Account  string
+	Network  string
+	Symbol   string
+	Decimals uint8
+	Before   *big.Int
+	After    *big.Int
+}
+
+// SendBalanceDigest sends all balance changes of a cycle as one alert,
+// split across as many messages as Discord's length limit requires
+func (c *Client) SendBalanceDigest(entries []DigestEntry) error {
+	if c == nil || len(entries) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = digestLine(e)
+	}
+
+	header := fmt.Sprintf("**📬 Balance Changes - %s** (%d)", time.Now().Format("2006-01-02 15:04"), len(entries))
+	for _, content := range chunkLines(header, lines, c.messageLimit) {
+		if err := c.sendAlert("balance_digest", content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func digestLine(e DigestEntry) string {
+	change := new(big.Int).Sub(e.After, e.Before)
+	emoji, sign := "📈", "+"
+	if change.Sign() < 0 {
+		emoji, sign = "📉", ""
+	}
+
+	return fmt.Sprintf("%s `%s` %s: %s%s %s (%s → %s)", emoji, formatAddress(e.Account), e.Network,
+		sign, formatTokenAmountSimple(change, e.Decimals), e.Symbol,
+		formatTokenAmountSimple(e.Before, e.Decimals), formatTokenAmountSimple(e.After, e.Decimals))
+}
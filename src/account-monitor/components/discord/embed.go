@@ -0,0 +1,248 @@
+package discord
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord embed limits
+const (
+	embedMaxFields      = 25
+	embedMaxTotalChars  = 6000
+	embedMaxDescription = 4096
+	embedMaxFieldName   = 256
+	embedMaxFieldValue  = 1024
+)
+
+const (
+	SummaryStyleCodeBlock = "codeblock"
+	SummaryStyleEmbed     = "embed"
+)
+
+const summaryEmbedColor = 0x2ECC71
+
+// buildSummaryEmbeds renders a DailySummary as one or more embeds with the
+// portfolio totals in the first description and one field per account,
+// starting a new embed whenever the field or character limits would be hit.
+func buildSummaryEmbeds(summary DailySummary) []Embed {
+	title := fmt.Sprintf("📊 Daily Portfolio Summary - %s", time.Now().Format("2006-01-02"))
+
+	var desc strings.Builder
+	desc.WriteString(fmt.Sprintf("Active Accounts: %d | Active Networks: %d\n",
+		summary.TotalAccounts, summary.ActiveNetworks))
+	if len(summary.UnavailableNetworks) > 0 {
+		desc.WriteString(unavailableNetworksLine(summary.UnavailableNetworks) + "\n")
+	}
+	for symbol, tokenTotal := range summary.TotalsByToken {
+		if tokenTotal.Total == nil || tokenTotal.Total.Cmp(big.NewInt(0)) == 0 {
+			continue
+		}
+		desc.WriteString(fmt.Sprintf("**%s** %s (%s)\n", symbol,
+			formatTokenAmountSimple(tokenTotal.Total, tokenTotal.Decimals),
+			formatTokenAmountSimple(tokenTotal.Change, tokenTotal.Decimals)))
+	}
+
+	current := Embed{
+		Title:       title,
+		Description: truncate(desc.String(), embedMaxDescription),
+		Color:       summaryEmbedColor,
+	}
+	size := len(current.Title) + len(current.Description)
+
+	var fields []EmbedField
+	if pivoted(summary) {
+		fields = groupedEmbedFields(summary)
+	} else {
+		for _, account := range summary.AccountSummaries {
+			fields = append(fields, EmbedField{
+				Name: truncate(fmt.Sprintf("%s (%s)%s%s", account.Name, formatAddress(account.Address),
+					addressTypeLabel(account.AddressType), mutedLabel(account.Muted)), embedMaxFieldName),
+				Value: truncate(accountFieldValue(account, summary.IncludeZeroBalances), embedMaxFieldValue),
+			})
+		}
+	}
+	if summary.OmittedAccounts > 0 {
+		fields = append(fields, EmbedField{
+			Name:  "Other Accounts",
+			Value: omittedAccountsLine(summary.OmittedAccounts),
+		})
+	}
+	if len(summary.Revenue) > 0 {
+		var value strings.Builder
+		for _, r := range summary.Revenue {
+			value.WriteString(fmt.Sprintf("**%s** %s\n", r.Symbol, revenueLine(r)))
+		}
+		fields = append(fields, EmbedField{
+			Name:  "Revenue Since Last Summary",
+			Value: truncate(value.String(), embedMaxFieldValue),
+		})
+	}
+	for _, v := range summary.ValidatorEstimates {
+		fields = append(fields, EmbedField{
+			Name: truncate(fmt.Sprintf("Validator %s (%s) on %s", v.Name, formatAddress(v.Address), v.Network),
+				embedMaxFieldName),
+			Value: fmt.Sprintf("Era %d: %d of %d points\nEstimated pending: ~%s %s (estimate)",
+				v.Era, v.Points, v.TotalPoints, formatTokenAmountSimple(v.Estimate, v.Decimals), v.Symbol),
+		})
+	}
+
+	for _, t := range summary.Treasuries {
+		fields = append(fields, EmbedField{
+			Name: truncate(fmt.Sprintf("Treasury %s (%s) on %s", t.Name, formatAddress(t.Address), t.Network),
+				embedMaxFieldName),
+			Value: fmt.Sprintf("Projected burn ~%s %s\n%s",
+				formatTokenAmountSimple(t.ProjectedBurn, t.Decimals), t.Symbol, treasuryBurnLine(t)),
+		})
+	}
+
+	if len(summary.StaleAccounts) > 0 {
+		var value strings.Builder
+		for _, a := range summary.StaleAccounts {
+			value.WriteString(fmt.Sprintf("⚠ Stale: %s\n", staleLine(a)))
+		}
+		fields = append(fields, EmbedField{
+			Name:  "Network Status",
+			Value: truncate(value.String(), embedMaxFieldValue),
+		})
+	}
+
+	var embeds []Embed
+	for _, field := range fields {
+		fieldSize := len(field.Name) + len(field.Value)
+
+		if len(current.Fields) >= embedMaxFields || size+fieldSize > embedMaxTotalChars {
+			embeds = append(embeds, current)
+			current = Embed{Title: title, Color: summaryEmbedColor}
+			size = len(current.Title)
+		}
+
+		current.Fields = append(current.Fields, field)
+		size += fieldSize
+	}
+	embeds = append(embeds, current)
+
+	if len(embeds) > 1 {
+		for i := range embeds {
+			embeds[i].Footer = &EmbedFooter{Text: fmt.Sprintf("Page %d/%d", i+1, len(embeds))}
+		}
+	}
+
+	return embeds
+}
+
+// accountFieldValue lists each non-zero token total and change for an
+// account, and zero totals too when includeZero is set
+func accountFieldValue(account AccountSummary, includeZero bool) string {
+	var value strings.Builder
+
+	decimals := make(map[string]uint8)
+	tokenTypes := make(map[string]string)
+	for _, tb := range account.TokenBalances {
+		decimals[tb.TokenKey()] = tb.Decimals
+		tokenTypes[tb.TokenKey()] = tb.TokenType
+	}
+
+	for key, total := range account.TotalsByToken {
+		if total == nil || (total.Cmp(big.NewInt(0)) == 0 && !includeZero) {
+			continue
+		}
+		d, ok := decimals[key]
+		if !ok {
+			d = 10
+		}
+		value.WriteString(fmt.Sprintf("%s: %s", key, formatTokenAmountSimple(total, d)))
+		if total.Sign() == 0 {
+			value.WriteString(" (zero)")
+		}
+		if change := account.ChangesByToken[key]; change != nil && change.Cmp(big.NewInt(0)) != 0 {
+			value.WriteString(fmt.Sprintf(" (%s)", formatTokenAmountSimple(change, d)))
+		}
+		value.WriteString(lpLabel(tokenTypes[key]))
+		value.WriteString("\n")
+	}
+
+	if len(account.TruncatedScans) > 0 {
+		value.WriteString(fmt.Sprintf("⚠ Asset scan truncated on %s\n", strings.Join(account.TruncatedScans, ", ")))
+	}
+
+	if includeZero && allZero(account) {
+		value.WriteString("∅ All balances zero (checked)\n")
+	}
+
+	if value.Len() == 0 {
+		return "No balances"
+	}
+	return value.String()
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
+
+func (c *Client) sendEmbed(embed Embed, isAlert bool) error {
+	if c == nil {
+		return nil
+	}
+	defer c.acquireSend()()
+
+	if c.isBot {
+		return c.sendBotEmbed(embed, isAlert)
+	}
+	return c.sendWebhookEmbed(embed)
+}
+
+func (c *Client) sendBotEmbed(embed Embed, isAlert bool) error {
+	if c.session == nil {
+		return fmt.Errorf("bot session not initialized")
+	}
+
+	channelID := c.summaryID
+	if isAlert && c.alertsID != "" {
+		channelID = c.alertsID
+	}
+
+	if channelID == "" {
+		return fmt.Errorf("no channel ID configured")
+	}
+
+	msgEmbed := &discordgo.MessageEmbed{
+		Title:       embed.Title,
+		Description: embed.Description,
+		Color:       embed.Color,
+		Timestamp:   embed.Timestamp,
+	}
+	for _, f := range embed.Fields {
+		msgEmbed.Fields = append(msgEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   f.Name,
+			Value:  f.Value,
+			Inline: f.Inline,
+		})
+	}
+	if embed.Footer != nil {
+		msgEmbed.Footer = &discordgo.MessageEmbedFooter{Text: embed.Footer.Text}
+	}
+
+	_, err := c.session.ChannelMessageSendEmbed(channelID, msgEmbed)
+	if err != nil {
+		log.Printf("Failed to send Discord bot embed: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) sendWebhookEmbed(embed Embed) error {
+	if c.webhookURL == "" {
+		return nil
+	}
+
+	return c.postWebhook(WebhookMessage{Embeds: []Embed{embed}})
+}
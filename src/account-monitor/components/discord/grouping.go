@@ -0,0 +1,194 @@
+package discord
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Values for DailySummary.GroupBy. Grouping only re-pivots the collected
+// account balances when the summary is rendered.
+const (
+	SummaryGroupAccount = "account"
+	SummaryGroupToken   = "token"
+	SummaryGroupNetwork = "network"
+)
+
+// pivoted reports whether the summary details are grouped by token or
+// network; any other GroupBy keeps them per account
+func pivoted(summary DailySummary) bool {
+	return summary.GroupBy == SummaryGroupToken || summary.GroupBy == SummaryGroupNetwork
+}
+
+// summaryGroup is a token's holdings within a summary view, with one member
+// per account holding it
+type summaryGroup struct {
+	Token    string
+	Decimals uint8
+	TokenLP  string
+	Total    *big.Int
+	Change   *big.Int
+	Members  []*summaryMember
+}
+
+type summaryMember struct {
+	Name   string
+	Amount *big.Int
+	Change *big.Int
+}
+
+// networkGroup is one network's holdings, by token
+type networkGroup struct {
+	Network string
+	Tokens  []*summaryGroup
+}
+
+// groupByToken pivots the account summaries into one group per token, with
+// each account's total across networks
+func groupByToken(summary DailySummary) []*summaryGroup {
+	return collectGroups(summary, func(*TokenBalance) bool { return true })
+}
+
+// groupByNetwork pivots the account summaries into each network's holdings,
+// by token and account
+func groupByNetwork(summary DailySummary) []networkGroup {
+	seen := make(map[string]bool)
+	var names []string
+	for _, account := range summary.AccountSummaries {
+		for _, tb := range account.TokenBalances {
+			if !seen[tb.Network] {
+				seen[tb.Network] = true
+				names = append(names, tb.Network)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var networks []networkGroup
+	for _, name := range names {
+		tokens := collectGroups(summary, func(tb *TokenBalance) bool { return tb.Network == name })
+		if len(tokens) > 0 {
+			networks = append(networks, networkGroup{Network: name, Tokens: tokens})
+		}
+	}
+	return networks
+}
+
+// collectGroups sums the balances accepted by include into per-token groups
+// sorted by token, with members sorted by account name. Zero balances are
+// left out unless the summary includes them.
+func collectGroups(summary DailySummary, include func(*TokenBalance) bool) []*summaryGroup {
+	groups := make(map[string]*summaryGroup)
+	for _, account := range summary.AccountSummaries {
+		members := make(map[string]*summaryMember)
+		for _, tb := range account.TokenBalances {
+			if tb.Balance == nil || !include(tb) || (tb.Balance.Sign() == 0 && !summary.IncludeZeroBalances) {
+				continue
+			}
+
+			key := tb.TokenKey()
+			group := groups[key]
+			if group == nil {
+				group = &summaryGroup{Token: key, Decimals: tb.Decimals, TokenLP: lpLabel(tb.TokenType),
+					Total: big.NewInt(0), Change: big.NewInt(0)}
+				groups[key] = group
+			}
+			member := members[key]
+			if member == nil {
+				member = &summaryMember{Name: account.Name, Amount: big.NewInt(0), Change: big.NewInt(0)}
+				members[key] = member
+				group.Members = append(group.Members, member)
+			}
+
+			group.Total.Add(group.Total, tb.Balance)
+			member.Amount.Add(member.Amount, tb.Balance)
+			if tb.Change != nil {
+				group.Change.Add(group.Change, tb.Change)
+				member.Change.Add(member.Change, tb.Change)
+			}
+		}
+	}
+
+	sorted := make([]*summaryGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.SliceStable(group.Members, func(i, j int) bool { return group.Members[i].Name < group.Members[j].Name })
+		sorted = append(sorted, group)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Token < sorted[j].Token })
+	return sorted
+}
+
+// writeGroupLines writes a token group's total and its per-account lines
+func writeGroupLines(out *strings.Builder, group *summaryGroup, indent string) {
+	out.WriteString(fmt.Sprintf("%s%-8s Total: %12s  Change: %12s%s\n", indent, group.Token+":",
+		formatTokenAmountSimple(group.Total, group.Decimals),
+		formatTokenAmountSimple(group.Change, group.Decimals), group.TokenLP))
+	for _, member := range group.Members {
+		out.WriteString(fmt.Sprintf("%s  %-20s %12s", indent, member.Name+":",
+			formatTokenAmountSimple(member.Amount, group.Decimals)))
+		if member.Change.Sign() != 0 {
+			out.WriteString(fmt.Sprintf(" (%s)", formatTokenAmountSimple(member.Change, group.Decimals)))
+		}
+		out.WriteString("\n")
+	}
+}
+
+// renderGroupedText writes the token or network view of the summary in
+// place of the account details
+func renderGroupedText(msg *strings.Builder, summary DailySummary) {
+	switch summary.GroupBy {
+	case SummaryGroupToken:
+		msg.WriteString("HOLDINGS BY TOKEN\n\n")
+		for _, group := range groupByToken(summary) {
+			writeGroupLines(msg, group, "")
+			msg.WriteString("\n")
+		}
+	case SummaryGroupNetwork:
+		msg.WriteString("HOLDINGS BY NETWORK\n\n")
+		for _, network := range groupByNetwork(summary) {
+			msg.WriteString(network.Network + "\n")
+			for _, group := range network.Tokens {
+				writeGroupLines(msg, group, "  ")
+			}
+			msg.WriteString("\n")
+		}
+	}
+	if summary.OmittedAccounts > 0 {
+		msg.WriteString(omittedAccountsLine(summary.OmittedAccounts) + "\n\n")
+	}
+}
+
+// groupedEmbedFields returns the token or network view of the summary as
+// embed fields
+func groupedEmbedFields(summary DailySummary) []EmbedField {
+	var fields []EmbedField
+	switch summary.GroupBy {
+	case SummaryGroupToken:
+		for _, group := range groupByToken(summary) {
+			var value strings.Builder
+			writeGroupLines(&value, group, "")
+			fields = append(fields, EmbedField{
+				Name:  truncate(group.Token, embedMaxFieldName),
+				Value: codeField(value.String()),
+			})
+		}
+	case SummaryGroupNetwork:
+		for _, network := range groupByNetwork(summary) {
+			var value strings.Builder
+			for _, group := range network.Tokens {
+				writeGroupLines(&value, group, "")
+			}
+			fields = append(fields, EmbedField{
+				Name:  truncate(network.Network, embedMaxFieldName),
+				Value: codeField(value.String()),
+			})
+		}
+	}
+	return fields
+}
+
+// codeField wraps text in a code block that fits an embed field value
+func codeField(text string) string {
+	return "```\n" + truncate(text, embedMaxFieldValue-8) + "```"
+}
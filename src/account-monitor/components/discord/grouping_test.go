@@ -0,0 +1,191 @@
+package discord
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+type holding struct {
+	network string
+	symbol  string
+	key     string
+	amount  int64
+	change  int64
+}
+
+// summaryAccount builds an account summary with its totals summed per token
+// key, the way the monitor accumulates them
+func summaryAccount(name string, holdings ...holding) AccountSummary {
+	account := AccountSummary{
+		Name:           name,
+		Address:        "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		TotalsByToken:  make(map[string]*big.Int),
+		ChangesByToken: make(map[string]*big.Int),
+	}
+	for _, h := range holdings {
+		tb := &TokenBalance{Key: h.key, Network: h.network, Symbol: h.symbol, Decimals: 4,
+			Balance: big.NewInt(h.amount), Change: big.NewInt(h.change), TokenType: "native"}
+		account.TokenBalances = append(account.TokenBalances, tb)
+
+		key := tb.TokenKey()
+		if account.TotalsByToken[key] == nil {
+			account.TotalsByToken[key] = big.NewInt(0)
+			account.ChangesByToken[key] = big.NewInt(0)
+		}
+		account.TotalsByToken[key].Add(account.TotalsByToken[key], tb.Balance)
+		account.ChangesByToken[key].Add(account.ChangesByToken[key], tb.Change)
+	}
+	return account
+}
+
+const usdtKey = "USDT@polkadot-assethub:1984"
+
+func groupingSummary(groupBy string) DailySummary {
+	return DailySummary{
+		GroupBy: groupBy,
+		AccountSummaries: []AccountSummary{
+			summaryAccount("treasury",
+				holding{network: "polkadot", symbol: "DOT", amount: 1_000_000, change: 100_000},
+				holding{network: "polkadot-assethub", symbol: "DOT", amount: 500_000},
+				holding{network: "polkadot-assethub", symbol: "USDT", key: usdtKey, amount: 70_000},
+			),
+			summaryAccount("ops",
+				holding{network: "polkadot", symbol: "DOT", amount: 300_000, change: -50_000},
+				holding{network: "kusama", symbol: "KSM", amount: 20_000, change: 20_000},
+				holding{network: "kusama", symbol: "DOT", amount: 0},
+			),
+		},
+	}
+}
+
+type groupTotal struct {
+	total  int64
+	change int64
+}
+
+func groupTotals(groups []*summaryGroup) map[string]groupTotal {
+	totals := make(map[string]groupTotal)
+	for _, g := range groups {
+		totals[g.Token] = groupTotal{g.Total.Int64(), g.Change.Int64()}
+	}
+	return totals
+}
+
+func checkTotals(t *testing.T, view string, got, want map[string]groupTotal) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got tokens %v, want %v", view, got, want)
+	}
+	for token, w := range want {
+		if g, ok := got[token]; !ok || g != w {
+			t.Errorf("%s %s: got total %d change %d, want %d %d", view, token, g.total, g.change, w.total, w.change)
+		}
+	}
+}
+
+// portfolio is the per-token total of every holding, which each view must
+// add back up to
+var portfolio = map[string]groupTotal{
+	"DOT":   {1_800_000, 50_000},
+	"KSM":   {20_000, 20_000},
+	usdtKey: {70_000, 0},
+}
+
+func TestGroupByTokenTotals(t *testing.T) {
+	groups := groupByToken(groupingSummary(SummaryGroupToken))
+	checkTotals(t, "token view", groupTotals(groups), portfolio)
+
+	for _, g := range groups {
+		if g.Token != "DOT" {
+			continue
+		}
+		members := make(map[string]groupTotal)
+		for _, m := range g.Members {
+			members[m.Name] = groupTotal{m.Amount.Int64(), m.Change.Int64()}
+		}
+		checkTotals(t, "DOT members", members, map[string]groupTotal{
+			"ops":      {300_000, -50_000},
+			"treasury": {1_500_000, 100_000},
+		})
+		if g.Members[0].Name != "ops" {
+			t.Errorf("DOT members not sorted by name: first is %s", g.Members[0].Name)
+		}
+	}
+}
+
+func TestGroupByNetworkTotals(t *testing.T) {
+	networks := groupByNetwork(groupingSummary(SummaryGroupNetwork))
+
+	want := map[string]map[string]groupTotal{
+		"kusama":            {"KSM": {20_000, 20_000}},
+		"polkadot":          {"DOT": {1_300_000, 50_000}},
+		"polkadot-assethub": {"DOT": {500_000, 0}, usdtKey: {70_000, 0}},
+	}
+	if len(networks) != len(want) {
+		t.Fatalf("got %d networks, want %d", len(networks), len(want))
+	}
+
+	sum := make(map[string]groupTotal)
+	for i, n := range networks {
+		if i > 0 && networks[i-1].Network > n.Network {
+			t.Errorf("networks not sorted: %s before %s", networks[i-1].Network, n.Network)
+		}
+		totals := groupTotals(n.Tokens)
+		checkTotals(t, n.Network, totals, want[n.Network])
+		for token, g := range totals {
+			s := sum[token]
+			sum[token] = groupTotal{s.total + g.total, s.change + g.change}
+		}
+	}
+	checkTotals(t, "network view", sum, portfolio)
+}
+
+func TestGroupByAccountTotals(t *testing.T) {
+	summary := groupingSummary(SummaryGroupAccount)
+	if pivoted(summary) {
+		t.Fatal("account grouping pivoted the summary")
+	}
+
+	text := renderSummaryText(summary)
+	if strings.Contains(text, "HOLDINGS BY") {
+		t.Error("account view rendered a grouped section")
+	}
+
+	sum := make(map[string]groupTotal)
+	for _, account := range summary.AccountSummaries {
+		for token, total := range account.TotalsByToken {
+			if total.Sign() == 0 {
+				continue
+			}
+			change := account.ChangesByToken[token]
+			line := fmt.Sprintf("  %-8s Total: %12s  Change: %12s", token+":",
+				formatTokenAmountSimple(total, 4), formatTokenAmountSimple(change, 4))
+			if !strings.Contains(text, line) {
+				t.Errorf("%s: missing total line %q", account.Name, line)
+			}
+			s := sum[token]
+			sum[token] = groupTotal{s.total + total.Int64(), s.change + change.Int64()}
+		}
+	}
+	checkTotals(t, "account view", sum, portfolio)
+}
+
+func TestGroupedTextTotals(t *testing.T) {
+	for _, groupBy := range []string{SummaryGroupToken, SummaryGroupNetwork} {
+		text := renderSummaryText(groupingSummary(groupBy))
+		if strings.Contains(text, "ACCOUNT DETAILS") {
+			t.Errorf("%s view also rendered the account details", groupBy)
+		}
+	}
+
+	text := renderSummaryText(groupingSummary(SummaryGroupToken))
+	for token, w := range portfolio {
+		line := fmt.Sprintf("%-8s Total: %12s  Change: %12s", token+":",
+			formatTokenAmountSimple(big.NewInt(w.total), 4), formatTokenAmountSimple(big.NewInt(w.change), 4))
+		if !strings.Contains(text, line) {
+			t.Errorf("token view missing total line %q", line)
+		}
+	}
+}
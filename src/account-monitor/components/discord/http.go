@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Default timeout for Discord HTTP requests
+const defaultHTTPTimeout = 10 * time.Second
+
+// HTTPOptions configures how the client reaches Discord
+type HTTPOptions struct {
+	// Timeout bounds each request; zero uses the 10 second default
+	Timeout time.Duration
+	// ProxyURL routes requests through an egress proxy. When empty the
+	// standard HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment is honored.
+	ProxyURL string
+}
+
+// proxy returns the proxy selector for the options
+func (o HTTPOptions) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if o.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(o.ProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid Discord proxy URL %q", o.ProxyURL)
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+// httpClient builds the HTTP client for the options
+func (o HTTPOptions) httpClient() (*http.Client, error) {
+	proxy, err := o.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
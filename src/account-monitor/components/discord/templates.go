@@ -0,0 +1,144 @@
+package discord
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplateFS embed.FS
+
+// Alert template names; each is loaded from <name>.tmpl
+const (
+	templateBalanceChange = "balance_change"
+	templateLowBalance    = "low_balance"
+	templateReaped        = "reaped"
+	templateChildBounty   = "child_bounty"
+	templateChildStatus   = "child_bounty_status"
+	templateValidator     = "validator"
+	templateOperational   = "operational"
+	templateRoleChange    = "role_change"
+	templateCollator      = "collator"
+	templateTreasuryBurn  = "treasury_burn"
+	templateAccountState  = "account_state"
+	templateProxyAnnounce = "proxy_announcement"
+	templateIdentity      = "identity"
+	templateFunded        = "funded"
+	templateAssetTrap     = "asset_trap"
+	templateReserved      = "reserved_cleared"
+	templateScheduled     = "scheduled_task"
+	templateWhale         = "whale_transfer"
+	templateApproval      = "asset_approval"
+)
+
+var templateNames = []string{
+	templateBalanceChange, templateLowBalance, templateReaped,
+	templateChildBounty, templateChildStatus, templateValidator, templateOperational, templateRoleChange,
+	templateCollator, templateTreasuryBurn, templateAccountState, templateProxyAnnounce,
+	templateIdentity, templateFunded, templateAssetTrap, templateReserved,
+	templateScheduled, templateWhale, templateApproval,
+}
+
+// AlertData is the data available to alert templates. Amounts are already
+// formatted with the token's decimals.
+type AlertData struct {
+	Account   string
+	Network   string
+	Token     string
+	Amount    string
+	Before    string
+	After     string
+	Change    string
+	Threshold string
+
+	Emoji   string
+	Type    string
+	Title   string
+	Message string
+
+	BountyID      uint64
+	ChildBountyID uint64
+	Curator       string
+	ParentCurator string
+
+	UnclaimedEras []uint
+	Claimable     string
+	Expired       string
+}
+
+// sampleAlertData exercises every field when validating templates
+var sampleAlertData = AlertData{
+	Account: "1abc...xyz", Network: "polkadot", Token: "DOT",
+	Amount: "1.0000", Before: "1.0000", After: "2.0000", Change: "+1.0000", Threshold: "0.5000",
+	Emoji: "📈", Type: "slash", Title: "Sample", Message: "Sample message",
+	BountyID: 1, ChildBountyID: 2, Curator: "1cur...xyz", ParentCurator: "1par...xyz",
+	UnclaimedEras: []uint{1}, Claimable: "1.0000", Expired: "1.0000",
+}
+
+var defaultTemplates = mustParseDefaultTemplates()
+
+func mustParseDefaultTemplates() map[string]*template.Template {
+	templates := make(map[string]*template.Template)
+	for _, name := range templateNames {
+		content, err := defaultTemplateFS.ReadFile("templates/" + name + ".tmpl")
+		if err != nil {
+			panic(err)
+		}
+		templates[name] = template.Must(template.New(name).Option("missingkey=error").Parse(string(content)))
+	}
+	return templates
+}
+
+// LoadTemplates overrides the built-in alert templates with any <name>.tmpl
+// files found in dir. A template that fails to parse or render sample data is
+// reported and the built-in one is used instead.
+func (c *Client) LoadTemplates(dir string) {
+	if c == nil || dir == "" {
+		return
+	}
+
+	c.templates = make(map[string]*template.Template)
+	for _, name := range templateNames {
+		path := filepath.Join(dir, name+".tmpl")
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("WARNING: failed to read template %s, using default: %v", path, err)
+			continue
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err == nil {
+			err = tmpl.Execute(&bytes.Buffer{}, sampleAlertData)
+		}
+		if err != nil {
+			log.Printf("WARNING: invalid template %s, using default: %v", path, err)
+			continue
+		}
+
+		log.Printf("Loaded notification template %s", path)
+		c.templates[name] = tmpl
+	}
+}
+
+// renderAlert renders the named alert template, preferring operator overrides
+func (c *Client) renderAlert(name string, data AlertData) (string, error) {
+	tmpl, ok := c.templates[name]
+	if !ok {
+		tmpl = defaultTemplates[name]
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
@@ -0,0 +1,89 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// Attempts of a webhook post answered with 429 Too Many Requests
+	webhookRateLimitAttempts = 3
+	// Longest Retry-After honored; a longer ban fails the send (and
+	// dead-letters an alert) instead of stalling every other send
+	webhookMaxRetryAfter = 60 * time.Second
+)
+
+// SetMaxConcurrentSends bounds how many messages are sent to Discord at
+// once; further sends wait for a free slot. n <= 0 leaves sends unbounded.
+func (c *Client) SetMaxConcurrentSends(n int) {
+	if c == nil || n <= 0 {
+		return
+	}
+	c.sendSlots = make(chan struct{}, n)
+}
+
+// acquireSend waits for a send slot and returns the function releasing it
+func (c *Client) acquireSend() func() {
+	if c.sendSlots == nil {
+		return func() {}
+	}
+	c.sendSlots <- struct{}{}
+	return func() { <-c.sendSlots }
+}
+
+// postWebhook posts a JSON payload to the webhook. A 429 response is
+// retried after the Retry-After Discord asks for; the caller's send slot is
+// held meanwhile, so a burst slows down rather than piling on more requests.
+func (c *Client) postWebhook(payload any) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Post(c.webhookURL, "application/json", bytes.NewBuffer(jsonData))
+		if err != nil {
+			log.Printf("Failed to send Discord webhook: %v", err)
+			return err
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent:
+			return nil
+		case resp.StatusCode != http.StatusTooManyRequests:
+			return fmt.Errorf("discord webhook returned status %d", resp.StatusCode)
+		}
+
+		wait := retryAfter(resp.Header, body)
+		if attempt >= webhookRateLimitAttempts || wait > webhookMaxRetryAfter {
+			return fmt.Errorf("discord webhook rate limited (retry after %v)", wait)
+		}
+		log.Printf("Discord webhook rate limited, retrying in %v", wait)
+		time.Sleep(wait)
+	}
+}
+
+// retryAfter reads how long Discord asks to wait from the Retry-After
+// header or the retry_after field of the body, both in seconds
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var limited struct {
+		RetryAfter float64 `json:"retry_after"`
+	}
+	seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64)
+	if err != nil || seconds <= 0 {
+		if json.Unmarshal(body, &limited) == nil && limited.RetryAfter > 0 {
+			seconds = limited.RetryAfter
+		} else {
+			seconds = 1
+		}
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+type Type string
+
+const (
+	BalanceChanged Type = "balance_changed"
+	LowBalance     Type = "low_balance"
+	Reaped         Type = "reaped"
+	// Funded is a zero to non-zero transition: the first funds seen on
+	// an account for a token
+	Funded Type = "funded"
+	// ReservedCleared is a native reserved balance dropping to zero, e.g. a
+	// slashed deposit or a force-removed identity or proxy. Before is the
+	// previous reserved balance and Change the change in total balance.
+	ReservedCleared Type = "reserved_cleared"
+)
+
+// Event describes a balance observation for one account/network/token
+type Event struct {
+	Type     Type
+	Account  types.Account
+	Network  string
+	Symbol   string
+	Decimals uint8
+	Before   *big.Int
+	After    *big.Int
+	Change   *big.Int
+	// Significant is set when the change crosses the notification threshold
+	Significant bool
+	// Digested is set when the change is reported in the cycle's digest
+	// instead of its own alert
+	Digested bool
+	// Muted is set while the account's alerts are silenced with !mute
+	Muted bool
+	Time  time.Time
+}
+
+// Subscriber consumes events. Subscribers run on the dispatch goroutine and
+// should not block for long.
+type Subscriber func(Event)
+
+// Bus decouples change detection from notification sinks. Publish never
+// blocks the monitor: when the buffer is full the event is dropped.
+type Bus struct {
+	ch          chan Event
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+func NewBus(buffer int) *Bus {
+	return &Bus{
+		ch: make(chan Event, buffer),
+	}
+}
+
+// Subscribe registers a consumer for all published events
+func (b *Bus) Subscribe(fn Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, fn)
+}
+
+// Publish queues an event for dispatch, dropping it with a warning if the
+// buffer is full
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	select {
+	case b.ch <- e:
+	default:
+		log.Printf("WARNING: event bus full, dropping %s event for %s on %s",
+			e.Type, e.Account.Address, e.Network)
+	}
+}
+
+// Run dispatches events to subscribers until ctx is canceled
+func (b *Bus) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-b.ch:
+			b.dispatch(e)
+		}
+	}
+}
+
+// Drain dispatches the events still buffered until none are left or ctx is
+// done, returning how many were left undelivered. Call it once Run has
+// returned so queued alerts aren't lost on shutdown.
+func (b *Bus) Drain(ctx context.Context) int {
+	for {
+		select {
+		case <-ctx.Done():
+			return len(b.ch)
+		case e := <-b.ch:
+			b.dispatch(e)
+		default:
+			return 0
+		}
+	}
+}
+
+func (b *Bus) dispatch(e Event) {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	for _, fn := range subscribers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Event subscriber panic recovered: %v", r)
+				}
+			}()
+			fn(e)
+		}()
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BalanceGauges holds the account_balance_total series, one per address,
+// network and symbol, for the Prometheus text exposition format
+type BalanceGauges struct {
+	mu     sync.RWMutex
+	series map[balanceLabels]float64
+}
+
+type balanceLabels struct {
+	address string
+	network string
+	symbol  string
+}
+
+func NewBalanceGauges() *BalanceGauges {
+	return &BalanceGauges{series: make(map[balanceLabels]float64)}
+}
+
+// Set records a balance in token units. A new series is refused once
+// limit series exist, returning false; limit <= 0 refuses all.
+func (g *BalanceGauges) Set(address, network, symbol string, value float64, limit int) bool {
+	key := balanceLabels{address: address, network: network, symbol: symbol}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.series[key]; !exists && len(g.series) >= limit {
+		return false
+	}
+	g.series[key] = value
+	return true
+}
+
+// WriteText writes the series in the Prometheus text exposition format,
+// sorted by labels so scrapes are stable
+func (g *BalanceGauges) WriteText(w io.Writer) error {
+	g.mu.RLock()
+	keys := make([]balanceLabels, 0, len(g.series))
+	for key := range g.series {
+		keys = append(keys, key)
+	}
+	values := make([]float64, len(keys))
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.address != b.address {
+			return a.address < b.address
+		}
+		if a.network != b.network {
+			return a.network < b.network
+		}
+		return a.symbol < b.symbol
+	})
+	for i, key := range keys {
+		values[i] = g.series[key]
+	}
+	g.mu.RUnlock()
+
+	var out strings.Builder
+	out.WriteString("# HELP account_balance_total Total balance of a monitored account in token units.\n")
+	out.WriteString("# TYPE account_balance_total gauge\n")
+	for i, key := range keys {
+		fmt.Fprintf(&out, "account_balance_total{address=\"%s\",network=\"%s\",symbol=\"%s\"} %g\n",
+			escapeLabel(key.address), escapeLabel(key.network), escapeLabel(key.symbol), values[i])
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// escapeLabel escapes a label value as the exposition format requires
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
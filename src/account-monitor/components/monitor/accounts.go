@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"log"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// dedupeAccounts collapses rows for the same public key (e.g. one address
+// imported in two SS58 formats) so balances aren't counted twice. The first
+// (lowest id) enabled row wins; a disabled row only stands in for the key
+// until an enabled one turns up, so disabling one copy doesn't stop the
+// other from being monitored.
+func dedupeAccounts(accounts []types.Account) []types.Account {
+	seen := make(map[string]int)
+	unique := make([]types.Account, 0, len(accounts))
+
+	for _, account := range accounts {
+		key, err := networks.PublicKeyHex(account.Address)
+		if err != nil {
+			// Not a substrate key (e.g. H160); compare the address itself
+			key = strings.ToLower(strings.TrimSpace(account.Address))
+		}
+
+		i, dup := seen[key]
+		if !dup {
+			seen[key] = len(unique)
+			unique = append(unique, account)
+			continue
+		}
+
+		kept := unique[i]
+		if !kept.MonitorEnabled && account.MonitorEnabled {
+			unique[i] = account
+			kept, account = account, kept
+		}
+		log.Printf("WARNING: account %d (%s) has the same public key as account %d (%s), skipping duplicate",
+			account.ID, account.Address, kept.ID, kept.Address)
+	}
+
+	return unique
+}
@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// approvalSnapshots holds the last approved amount per delegate, by
+// account, network and asset
+type approvalSnapshots map[string]map[string]*big.Int
+
+// checkAssetApprovals reads the approvals an account granted on an Assets
+// pallet asset it holds, lists them in the account details and alerts when
+// one appears or is raised. An approval lets the delegate move the funds
+// much like a proxy does, so it alerts under the proxy bit. The first read
+// of each asset is recorded without alerting.
+func (m *Monitor) checkAssetApprovals(account types.Account, network types.Network, token types.NetworkToken,
+	balance *big.Int, tokenBal *discord.TokenBalance) {
+	approvals, err := m.networks.GetAssetApprovals(network.Name, account.Address, token.TokenID.String)
+	m.counters.rpc(err)
+	if err != nil {
+		log.Printf("    Failed to read approvals of %s (%s): %v", token.Symbol, token.TokenID.String, err)
+		return
+	}
+
+	key := fmt.Sprintf("%d:%d:%s", account.ID, network.ID, token.TokenID.String)
+	previous, seen := m.approvals[key]
+	current := make(map[string]*big.Int, len(approvals))
+	m.approvals[key] = current
+
+	for _, a := range approvals {
+		current[a.Delegate] = a.Amount
+		if tokenBal != nil {
+			tokenBal.Approvals = append(tokenBal.Approvals, discord.Approval{
+				Delegate:        a.Delegate,
+				Amount:          a.Amount,
+				Deposit:         a.Deposit,
+				DepositSymbol:   network.Symbol.String,
+				DepositDecimals: network.Decimals,
+			})
+		}
+
+		before, existed := previous[a.Delegate]
+		if !seen || (existed && a.Amount.Cmp(before) <= 0) {
+			continue
+		}
+
+		log.Printf("  WARNING: %s approved %s to transfer %v of %s (%s) on %s (deposit %v)",
+			account.Address, a.Delegate, a.Amount, token.Symbol, token.TokenID.String, network.Name, a.Deposit)
+		if m.config.Load().EnableNotifications && account.Notifies(types.AlertApproval) {
+			if err := m.discord.SendAssetApprovalAlert(account.Address, network.Name, token.Symbol, a.Delegate,
+				a.Amount, balance, a.Deposit, token.Decimals, network.Symbol.String, network.Decimals); err != nil {
+				log.Printf("Failed to send asset approval alert: %v", err)
+			}
+		}
+	}
+
+	for delegate := range previous {
+		if _, ok := current[delegate]; !ok {
+			log.Printf("  Approval of %s (%s) to %s on %s was used up or cancelled; its deposit is released",
+				token.Symbol, token.TokenID.String, delegate, network.Name)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// HandleAssetChanges is called by discovery with the assets that appeared or
+// disappeared on a chain. A vanished asset still held by a monitored account
+// usually means it was destroyed, so the holders are alerted once; a new
+// asset is only announced to monitored accounts that already hold it.
+func (m *Monitor) HandleAssetChanges(changes []networks.AssetChange) {
+	var networkName string
+	if err := m.db.QueryRow(`SELECT name FROM networks WHERE id = ?`, changes[0].NetworkID).Scan(&networkName); err != nil {
+		log.Printf("Failed to get network %d for asset changes: %v", changes[0].NetworkID, err)
+		return
+	}
+
+	var appeared []networks.AssetChange
+	for _, change := range changes {
+		if change.Appeared {
+			appeared = append(appeared, change)
+			continue
+		}
+		log.Printf("  Asset %s (%s %s) disappeared from %s", change.Symbol, change.TokenType, change.AssetID, networkName)
+		m.alertAssetHolders(networkName, change)
+	}
+
+	if len(appeared) == 0 {
+		return
+	}
+	log.Printf("  %d new asset(s) appeared on %s", len(appeared), networkName)
+
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts for new assets: %v", err)
+		return
+	}
+	for _, change := range appeared {
+		for _, account := range accounts {
+			balance, err := m.networks.GetAssetBalance(networkName, account.Address, change.TokenType, change.AssetID)
+			if err != nil || balance.Total == nil || balance.Total.Sign() == 0 {
+				continue
+			}
+			log.Printf("  %s holds new asset %s (%s) on %s", account.Address, change.Symbol, change.AssetID, networkName)
+			if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+				message := fmt.Sprintf("New asset %s (id %s) appeared on chain and this account holds %s of it",
+					change.Symbol, change.AssetID, balance.Total)
+				if err := m.discord.SendAccountStateAlert(account.Address, networkName, "new asset", message); err != nil {
+					log.Printf("Failed to send account state alert: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// alertAssetHolders alerts the monitored accounts whose last stored balance
+// of a vanished asset was non-zero
+func (m *Monitor) alertAssetHolders(networkName string, change networks.AssetChange) {
+	rows, err := m.db.Query(`
+		SELECT a.id, a.address, a.discord_notify, a.notify_mask, b.total
+		FROM balances b
+		JOIN accounts a ON a.id = b.account_id
+		JOIN network_tokens nt ON nt.id = b.network_token_id
+		WHERE nt.network_id = ? AND nt.token_type = ? AND nt.token_id = ?
+		  AND a.monitor_enabled = TRUE
+	`, change.NetworkID, change.TokenType, change.AssetID)
+	if err != nil {
+		log.Printf("Failed to get holders of asset %s: %v", change.AssetID, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account types.Account
+		var total string
+		if err := rows.Scan(&account.ID, &account.Address, &account.DiscordNotify, &account.NotifyMask, &total); err != nil {
+			log.Printf("Failed to scan asset holder: %v", err)
+			continue
+		}
+		if amount, ok := new(big.Int).SetString(total, 10); !ok || amount.Sign() == 0 {
+			continue
+		}
+
+		log.Printf("  WARNING: %s held %s of vanished asset %s on %s", account.Address, total, change.Symbol, networkName)
+		if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+			message := fmt.Sprintf("Asset %s (id %s) is no longer registered on chain; it was likely destroyed. Last known balance: %s",
+				change.Symbol, change.AssetID, total)
+			if err := m.discord.SendAccountStateAlert(account.Address, networkName, "asset disappeared", message); err != nil {
+				log.Printf("Failed to send account state alert: %v", err)
+			}
+		}
+	}
+}
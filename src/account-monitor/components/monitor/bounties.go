@@ -0,0 +1,236 @@
+package monitor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+func (m *Monitor) checkBounties(ctx context.Context) {
+	log.Println("Starting bounty check...")
+
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	// Index monitored accounts by public key so addresses in any network
+	// format match
+	monitored := make(map[string]types.Account)
+	for _, account := range accounts {
+		if key, err := networks.PublicKeyHex(account.Address); err == nil {
+			monitored[key] = account
+		}
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	for _, network := range networkList {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if detected, err := m.db.HasPallet(network.ID, "ChildBounties"); err != nil || !detected {
+			continue
+		}
+
+		childBounties, err := m.networks.GetChildBounties(network.Name)
+		if err != nil {
+			log.Printf("Failed to get child bounties on %s: %v", network.Name, err)
+			continue
+		}
+
+		blockNumber, err := m.networks.GetBlockNumber(network.Name)
+		if err != nil {
+			log.Printf("Failed to get block number on %s: %v", network.Name, err)
+			continue
+		}
+
+		var nativeToken types.NetworkToken
+		err = m.db.QueryRow(`
+			SELECT id, symbol, decimals FROM network_tokens 
+			WHERE network_id = ? AND token_type = 'native'
+		`, network.ID).Scan(&nativeToken.ID, &nativeToken.Symbol, &nativeToken.Decimals)
+		if err != nil {
+			log.Printf("Failed to get native token for network %s: %v", network.Name, err)
+			continue
+		}
+
+		// Parent bounty row ids, stored once per network per check
+		parentRows := make(map[uint32]uint)
+
+		for _, cb := range childBounties {
+			beneficiary, isBeneficiary := lookupMonitored(monitored, cb.Beneficiary)
+			_, isCurator := lookupMonitored(monitored, cb.Curator)
+			_, isParentCurator := lookupMonitored(monitored, cb.ParentCurator)
+			if !isBeneficiary && !isCurator && !isParentCurator {
+				continue
+			}
+
+			// A pending payout becomes claimable once unlock_at is reached
+			status := cb.Status
+			if status == "pending_payout" {
+				status = "pending_award"
+				if blockNumber >= uint64(cb.UnlockAt) {
+					status = "awarded"
+				}
+			}
+
+			bountyRowID, stored := parentRows[cb.ParentID]
+			if !stored {
+				parent, err := m.networks.GetBounty(network.Name, cb.ParentID)
+				if err != nil {
+					log.Printf("Failed to get parent bounty %d on %s: %v", cb.ParentID, network.Name, err)
+					continue
+				}
+				bountyRowID, err = m.db.UpsertBounty(parent.Bounty(network.ID))
+				if err != nil {
+					log.Printf("Failed to store bounty %d on %s: %v", cb.ParentID, network.Name, err)
+					continue
+				}
+				parentRows[cb.ParentID] = bountyRowID
+			}
+
+			previous, err := m.storeChildBounty(bountyRowID, nativeToken, cb, status)
+			if err != nil {
+				log.Printf("Failed to store child bounty %d/%d on %s: %v", cb.ParentID, cb.ID, network.Name, err)
+				continue
+			}
+
+			if status != previous.Status || cb.Curator != previous.Curator {
+				m.notifyChildBountyStatus(network.Name, nativeToken, cb, status, blockNumber, monitored)
+			}
+
+			if status == "awarded" && previous.Status != "awarded" && isBeneficiary &&
+				m.config.Load().EnableNotifications && beneficiary.Notifies(types.AlertBounty) {
+				err := m.discord.SendChildBountyAlert(cb.Beneficiary, network.Name, uint64(cb.ParentID), uint64(cb.ID),
+					cb.Value, nativeToken.Symbol, nativeToken.Decimals, cb.Curator, cb.ParentCurator)
+				if err != nil {
+					log.Printf("Failed to send child bounty alert: %v", err)
+				}
+			}
+		}
+	}
+
+	log.Println("Bounty check completed")
+}
+
+// notifyChildBountyStatus alerts whoever must act on a child bounty's new
+// state before the payout is claimable: the parent curator when it is added
+// and needs a curator, the proposed curator who must accept the role, and
+// the beneficiary once it is awarded and waiting to unlock. The claim-ready
+// alert is sent separately.
+func (m *Monitor) notifyChildBountyStatus(networkName string, token types.NetworkToken, cb networks.ChildBountyInfo,
+	status string, blockNumber uint64, monitored map[string]types.Account) {
+
+	update := discord.ChildBountyUpdate{
+		BountyID:      uint64(cb.ParentID),
+		ChildBountyID: uint64(cb.ID),
+		Amount:        cb.Value,
+		Token:         token.Symbol,
+		Decimals:      token.Decimals,
+		Curator:       cb.Curator,
+		ParentCurator: cb.ParentCurator,
+	}
+
+	var recipient string
+	switch status {
+	case "added":
+		recipient = cb.ParentCurator
+		update.Emoji, update.Title = "🆕", "Added"
+		update.Message = "Awaiting a curator: propose one with `childBounties.proposeCurator` or award it directly"
+	case "curator_proposed":
+		recipient = cb.Curator
+		update.Emoji, update.Title = "🧑‍⚖️", "Curator Proposed"
+		update.Message = "You were proposed as curator: accept with `childBounties.acceptCurator` to take the role"
+	case "pending_award":
+		recipient = cb.Beneficiary
+		update.Emoji, update.Title = "🏅", "Awarded"
+		update.Message = fmt.Sprintf("Payout unlocks at block %d (in %d blocks)", cb.UnlockAt, uint64(cb.UnlockAt)-blockNumber)
+	default:
+		return
+	}
+
+	account, ok := lookupMonitored(monitored, recipient)
+	if !ok {
+		return
+	}
+
+	log.Printf("Child bounty %d/%d on %s is %s", cb.ParentID, cb.ID, networkName, status)
+	if m.config.Load().EnableNotifications && account.Notifies(types.AlertBounty) {
+		if err := m.discord.SendChildBountyStatusAlert(recipient, networkName, update); err != nil {
+			log.Printf("Failed to send child bounty status alert: %v", err)
+		}
+	}
+}
+
+// childBountyState is a child bounty's stored status and curator
+type childBountyState struct {
+	Status  string
+	Curator string
+}
+
+// storeChildBounty upserts a child bounty under its parent's row, returning
+// the child bounty's previously stored status and curator
+func (m *Monitor) storeChildBounty(bountyRowID uint, token types.NetworkToken,
+	cb networks.ChildBountyInfo, status string) (childBountyState, error) {
+
+	var previous childBountyState
+	var curator sql.NullString
+	err := m.db.QueryRow(`
+		SELECT status, curator_address FROM child_bounties WHERE bounty_id = ? AND child_bounty_id = ?
+	`, bountyRowID, cb.ID).Scan(&previous.Status, &curator)
+	if err != nil && err != sql.ErrNoRows {
+		return previous, err
+	}
+	previous.Curator = curator.String
+
+	_, err = m.db.Exec(`
+		INSERT INTO child_bounties
+		(bounty_id, child_bounty_id, network_token_id, curator_address, beneficiary_address,
+		 value, fee, status, awarded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, IF(? = 'awarded', NOW(), NULL))
+		ON DUPLICATE KEY UPDATE
+		curator_address = VALUES(curator_address),
+		beneficiary_address = VALUES(beneficiary_address),
+		value = VALUES(value),
+		fee = VALUES(fee),
+		status = VALUES(status),
+		awarded_at = COALESCE(awarded_at, VALUES(awarded_at))
+	`, bountyRowID, cb.ID, token.ID, nullString(cb.Curator), nullString(cb.Beneficiary),
+		cb.Value.String(), cb.Fee.String(), status, status)
+	if err != nil {
+		return previous, err
+	}
+
+	return previous, nil
+}
+
+// lookupMonitored finds the monitored account for an address, if any
+func lookupMonitored(monitored map[string]types.Account, address string) (types.Account, bool) {
+	if address == "" {
+		return types.Account{}, false
+	}
+	key, err := networks.PublicKeyHex(address)
+	if err != nil {
+		return types.Account{}, false
+	}
+	account, ok := monitored[key]
+	return account, ok
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
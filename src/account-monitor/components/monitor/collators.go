@@ -0,0 +1,131 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// collatorIssues is the set of liveness problems last seen for a collator
+type collatorIssues struct {
+	NotSelected bool
+	LowBond     bool
+	Offline     bool
+}
+
+// checkCollators checks every active collator role for selection into the
+// active set, a bond below the candidacy minimum and missed authorship,
+// alerting when a problem appears or clears
+func (m *Monitor) checkCollators(ctx context.Context) {
+	rows, err := m.db.Query(`
+		SELECT a.id, a.address, a.name, a.discord_notify, a.notify_mask, n.id, n.name, nt.symbol, nt.decimals
+		FROM account_roles ar
+		JOIN accounts a ON a.id = ar.account_id
+		JOIN networks n ON n.id = ar.network_id
+		JOIN network_tokens nt ON nt.network_id = n.id AND nt.token_type = 'native'
+		WHERE ar.role_type = 'collator' AND ar.active = TRUE AND a.monitor_enabled = TRUE AND n.active = TRUE
+	`)
+	if err != nil {
+		log.Printf("Failed to get collators: %v", err)
+		return
+	}
+
+	type collator struct {
+		account  types.Account
+		network  types.Network
+		symbol   string
+		decimals uint8
+	}
+	var collators []collator
+	for rows.Next() {
+		var c collator
+		if err := rows.Scan(&c.account.ID, &c.account.Address, &c.account.Name, &c.account.DiscordNotify, &c.account.NotifyMask,
+			&c.network.ID, &c.network.Name, &c.symbol, &c.decimals); err != nil {
+			log.Printf("Failed to scan collator: %v", err)
+			continue
+		}
+		collators = append(collators, c)
+	}
+	rows.Close()
+
+	sessions := uint32(max(m.config.Load().CollatorOfflineSessions, 0))
+
+	for _, c := range collators {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		status, err := m.networks.GetCollatorStatus(c.network.Name, c.account.Address, sessions)
+		if err != nil {
+			log.Printf("Failed to check collator %s on %s: %v", c.account.Address, c.network.Name, err)
+			continue
+		}
+		if status == nil {
+			continue
+		}
+
+		current := collatorIssues{
+			NotSelected: !status.Selected,
+			LowBond:     status.Bond != nil && status.MinBond != nil && status.Bond.Cmp(status.MinBond) < 0,
+			Offline:     sessions > 0 && status.AuthorshipKnown && status.MissedSessions >= sessions,
+		}
+
+		log.Printf("Collator %s on %s (%s): selected=%v missed_sessions=%d",
+			c.account.Address, c.network.Name, status.Pallet, status.Selected, status.MissedSessions)
+
+		key := fmt.Sprintf("%d:%d", c.account.ID, c.network.ID)
+		m.collatorMu.Lock()
+		previous := m.collatorIssues[key]
+		m.collatorIssues[key] = current
+		m.collatorMu.Unlock()
+
+		if !m.config.Load().EnableNotifications || !c.account.Notifies(types.AlertValidator) {
+			continue
+		}
+
+		m.collatorTransition(c.account.Address, c.network.Name, previous.NotSelected, current.NotSelected,
+			"not selected", "Collator is not in the active set",
+			"selected", "Collator is back in the active set")
+
+		if previous.LowBond != current.LowBond {
+			bond, min := "unknown", "unknown"
+			if status.Bond != nil {
+				bond = discord.FormatTokenAmount(status.Bond, c.decimals) + " " + c.symbol
+			}
+			if status.MinBond != nil {
+				min = discord.FormatTokenAmount(status.MinBond, c.decimals) + " " + c.symbol
+			}
+			m.collatorTransition(c.account.Address, c.network.Name, previous.LowBond, current.LowBond,
+				"bond below minimum", fmt.Sprintf("Candidate bond %s is below the minimum %s", bond, min),
+				"bond restored", fmt.Sprintf("Candidate bond %s meets the minimum %s", bond, min))
+		}
+
+		m.collatorTransition(c.account.Address, c.network.Name, previous.Offline, current.Offline,
+			"offline", fmt.Sprintf("No block authored in the last %d sessions", status.MissedSessions),
+			"authoring", "Collator is authoring blocks again")
+	}
+}
+
+// collatorTransition sends the problem or recovery alert when a collator
+// issue changes state
+func (m *Monitor) collatorTransition(address, network string, was, is bool,
+	problemTitle, problemMessage, recoveredTitle, recoveredMessage string) {
+
+	if was == is {
+		return
+	}
+
+	title, message := problemTitle, problemMessage
+	if !is {
+		title, message = recoveredTitle, recoveredMessage
+	}
+
+	if err := m.discord.SendCollatorAlert(address, network, title, message, !is); err != nil {
+		log.Printf("Failed to send collator alert: %v", err)
+	}
+}
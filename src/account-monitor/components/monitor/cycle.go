@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// resumeCycle returns the balance cycle to run and the account/network pairs
+// it already processed. An unfinished cycle left by a crash or restart is
+// resumed; otherwise a new cycle starts, identified by its start time.
+func (m *Monitor) resumeCycle() (int64, map[string]bool) {
+	cycleID, done, err := m.db.GetCycleProgress()
+	if err != nil {
+		log.Printf("Failed to read balance cycle progress, starting a new cycle: %v", err)
+	} else if cycleID != 0 {
+		log.Printf("Resuming balance cycle started %s: %d account/network pairs already processed",
+			time.Unix(cycleID, 0).Format(time.RFC3339), len(done))
+		return cycleID, done
+	}
+
+	return time.Now().Unix(), make(map[string]bool)
+}
+
+// cyclePair keys an account/network pair in cycle progress
+func cyclePair(account types.Account, network types.Network) string {
+	return fmt.Sprintf("%d:%d", account.ID, network.ID)
+}
+
+// restoreProgress adds the balances an interrupted cycle already stored for
+// an account on a network to the summary, so a resumed cycle reports the
+// whole cycle. Changes are summed from the history recorded since the cycle
+// started.
+func (m *Monitor) restoreProgress(cycleID int64, account types.Account, network types.Network,
+	accountBalance *AccountBalance, portfolioTotalsByToken, portfolioChangesByToken map[string]*big.Int) {
+
+	changes := make(map[uint]*big.Int)
+	rows, err := m.db.Query(`
+		SELECT network_token_id, change_amount FROM balance_history
+		WHERE account_id = ? AND network_id = ? AND recorded_at >= FROM_UNIXTIME(?)
+	`, account.ID, network.ID, cycleID)
+	if err != nil {
+		log.Printf("  Failed to restore cycle changes for %s on %s: %v", account.Address, network.Name, err)
+		return
+	}
+	for rows.Next() {
+		var tokenID uint
+		var amount string
+		if err := rows.Scan(&tokenID, &amount); err != nil {
+			continue
+		}
+		if value, ok := new(big.Int).SetString(amount, 10); ok {
+			if changes[tokenID] == nil {
+				changes[tokenID] = big.NewInt(0)
+			}
+			changes[tokenID].Add(changes[tokenID], value)
+		}
+	}
+	rows.Close()
+
+	rows, err = m.db.Query(`
+		SELECT nt.id, nt.symbol, nt.decimals, nt.token_id, nt.token_type, nt.summary_min_balance, b.total, b.bonded
+		FROM balances b
+		JOIN network_tokens nt ON nt.id = b.network_token_id
+		WHERE b.account_id = ? AND b.network_id = ?
+		ORDER BY nt.token_type, CAST(nt.token_id AS UNSIGNED)
+	`, account.ID, network.ID)
+	if err != nil {
+		log.Printf("  Failed to restore cycle balances for %s on %s: %v", account.Address, network.Name, err)
+		return
+	}
+	defer rows.Close()
+
+	assetTypes := m.assetTokenTypes(network)
+	for rows.Next() {
+		var token types.NetworkToken
+		var total, bonded string
+		if err := rows.Scan(&token.ID, &token.Symbol, &token.Decimals, &token.TokenID, &token.TokenType,
+			&token.SummaryMinBalance, &total, &bonded); err != nil {
+			continue
+		}
+
+		balance, ok := new(big.Int).SetString(total, 10)
+		if !ok {
+			continue
+		}
+		scanned := token.TokenType == "erc20" || slices.Contains(assetTypes, token.TokenType)
+		if token.TokenType != "native" && (balance.Sign() == 0 || !scanned ||
+			m.belowSummaryMinimum(token, balance)) {
+			continue
+		}
+		bondedValue, ok := new(big.Int).SetString(bonded, 10)
+		if !ok {
+			bondedValue = big.NewInt(0)
+		}
+		change := changes[token.ID]
+		if change == nil {
+			change = big.NewInt(0)
+		}
+
+		addTokenBalance(&discord.TokenBalance{
+			Key:       tokenKey(network, token, token.TokenType),
+			Network:   network.Name,
+			Balance:   balance,
+			Symbol:    token.Symbol,
+			Decimals:  token.Decimals,
+			Change:    change,
+			Bonded:    bondedValue,
+			TokenType: token.TokenType,
+		}, accountBalance, portfolioTotalsByToken, portfolioChangesByToken)
+	}
+}
@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// delegation is an account's DelegatedStaking delegation on a network
+type delegation struct {
+	agent  string
+	amount *big.Int
+}
+
+// checkDelegation alerts when an account starts or stops delegating
+// through DelegatedStaking, switches agent or its delegated amount changes.
+// The first read of each account and network is the baseline.
+func (m *Monitor) checkDelegation(account types.Account, network types.Network, balance types.Balance) {
+	current := delegation{agent: balance.DelegatedTo, amount: balance.Delegated}
+	if current.amount == nil {
+		current.amount = big.NewInt(0)
+	}
+
+	key := fmt.Sprintf("%d:%d", account.ID, network.ID)
+	m.delegationMu.Lock()
+	previous, seen := m.delegations[key]
+	m.delegations[key] = current
+	m.delegationMu.Unlock()
+
+	if !seen || (previous.agent == current.agent && previous.amount.Cmp(current.amount) == 0) {
+		return
+	}
+
+	amount := func(d delegation) string {
+		return fmt.Sprintf("%s %s", discord.FormatTokenAmount(d.amount, network.Decimals), network.Symbol.String)
+	}
+	var message string
+	switch {
+	case previous.agent == "":
+		message = fmt.Sprintf("Now delegating %s to agent `%s`.", amount(current), current.agent)
+	case current.agent == "":
+		message = fmt.Sprintf("No longer delegating; %s was delegated to agent `%s`.", amount(previous), previous.agent)
+	case previous.agent != current.agent:
+		message = fmt.Sprintf("Delegation moved from agent `%s` to `%s` (%s → %s).",
+			previous.agent, current.agent, amount(previous), amount(current))
+	default:
+		message = fmt.Sprintf("Delegation to agent `%s` changed from %s to %s.", current.agent, amount(previous), amount(current))
+	}
+
+	log.Printf("  %s on %s: %s", account.Address, network.Name, message)
+	if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+		if err := m.discord.SendAccountStateAlert(account.Address, network.Name, "delegation changed", message); err != nil {
+			log.Printf("Failed to send account state alert: %v", err)
+		}
+	}
+}
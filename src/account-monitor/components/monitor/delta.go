@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// runDeltaCycle starts a delta check in the background unless a balance
+// check of either kind is still running
+func (m *Monitor) runDeltaCycle(ctx context.Context) {
+	if !m.balanceCycleRunning.CompareAndSwap(false, true) {
+		log.Println("Balance check still running, skipping this delta check")
+		return
+	}
+
+	m.cycles.Add(1)
+	go func() {
+		defer m.cycles.Done()
+		defer m.balanceCycleRunning.Store(false)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Delta check panic recovered: %v", r)
+			}
+		}()
+
+		m.checkActiveBalances(ctx)
+	}()
+}
+
+// checkActiveBalances re-reads the native balances of the accounts whose
+// balance changed within the last DeltaActiveHours, between full cycles.
+// Changes alert as in a full cycle; assets, freezes, delegation and the
+// summary are left to the full cycle.
+func (m *Monitor) checkActiveBalances(ctx context.Context) {
+	since := time.Now().Add(-time.Duration(m.config.Load().DeltaActiveHours) * time.Hour)
+	active, err := m.db.GetChangedAccounts(since)
+	if err != nil {
+		log.Printf("Failed to get recently changed accounts: %v", err)
+		return
+	}
+	if len(active) == 0 {
+		return
+	}
+
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+	accountNetworks, err := m.db.GetAccountNetworks()
+	if err != nil {
+		log.Printf("Failed to get account network restrictions, checking all networks: %v", err)
+	}
+
+	log.Printf("Starting delta check of %d accounts changed since %s...", len(active), since.UTC().Format(time.RFC3339))
+	start := time.Now()
+
+	// The delta check doesn't feed a summary
+	accountBalance := &AccountBalance{
+		TotalsByToken:  make(map[string]*big.Int),
+		ChangesByToken: make(map[string]*big.Int),
+	}
+	totals := make(map[string]*big.Int)
+	changes := make(map[string]*big.Int)
+
+	checked := make(map[uint]bool)
+	unavailable := make(map[string]bool)
+	processed := 0
+	for _, account := range dedupeAccounts(accounts) {
+		if !active[account.ID] || !account.MonitorEnabled || isEVMAccount(account) {
+			continue
+		}
+
+		for _, network := range networkList {
+			select {
+			case <-ctx.Done():
+				m.sendDigest()
+				return
+			default:
+			}
+
+			if !network.Active || unavailable[network.Name] {
+				continue
+			}
+			if only, ok := accountNetworks[account.ID]; ok && !only[network.ID] {
+				continue
+			}
+
+			read := m.networks.GetBalance
+			if account.Profile == types.ProfileMinimal {
+				read = m.networks.GetNativeBalance
+			}
+			balance, err := read(network.Name, account.Address)
+			m.counters.rpc(err)
+			if err != nil {
+				log.Printf("  Failed to get balance for %s on %s: %v", account.Address, network.Name, err)
+				if rpcUnavailable(err) {
+					unavailable[network.Name] = true
+				}
+				continue
+			}
+			m.markAccountChecked(account, checked)
+
+			nativeToken, err := m.nativeToken(network)
+			if err != nil {
+				log.Printf("  Failed to get native token for network %s: %v", network.Name, err)
+				continue
+			}
+			m.checkRefState(account, network, balance)
+			m.processTokenBalance(account, network, nativeToken, balance, accountBalance, totals, changes, "native")
+		}
+		processed++
+	}
+
+	m.sendDigest()
+	log.Printf("Delta check of %d accounts completed in %v", processed, time.Since(start).Round(time.Second))
+}
+
+// nativeToken returns the network's native token
+func (m *Monitor) nativeToken(network types.Network) (types.NetworkToken, error) {
+	var token types.NetworkToken
+	err := m.db.QueryRow(`
+		SELECT id, symbol, decimals FROM network_tokens 
+		WHERE network_id = ? AND token_type = 'native'
+	`, network.ID).Scan(&token.ID, &token.Symbol, &token.Decimals)
+	if err != nil {
+		return token, fmt.Errorf("native token of %s: %w", network.Name, err)
+	}
+	return token, nil
+}
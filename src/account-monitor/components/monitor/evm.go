@@ -0,0 +1,85 @@
+package monitor
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// isEVMAccount reports whether the account is an H160 address, which is
+// only readable through a network's Ethereum RPC
+func isEVMAccount(account types.Account) bool {
+	return networks.IsEVMAddress(account.Address)
+}
+
+// checkEVMBalances reads an H160 account's native balance and its balances
+// of the network's active erc20 tokens through the network's evm_rpc_url.
+// It stops at the first RPC failure and returns it, so the caller can skip
+// an unavailable network; other per-token failures are only logged.
+func (m *Monitor) checkEVMBalances(account types.Account, network types.Network, accountBalance *AccountBalance,
+	portfolioTotalsByToken, portfolioChangesByToken map[string]*big.Int, vlog debugLog) error {
+
+	balance, err := m.networks.GetEVMBalance(network.Name, account.Address)
+	m.counters.rpc(err)
+	if err != nil {
+		return err
+	}
+
+	var nativeToken types.NetworkToken
+	err = m.db.QueryRow(`
+		SELECT id, symbol, decimals FROM network_tokens
+		WHERE network_id = ? AND token_type = 'native'
+	`, network.ID).Scan(&nativeToken.ID, &nativeToken.Symbol, &nativeToken.Decimals)
+	if err != nil {
+		return fmt.Errorf("failed to get native token for network %s: %w", network.Name, err)
+	}
+	m.processTokenBalance(account, network, nativeToken, balance, accountBalance,
+		portfolioTotalsByToken, portfolioChangesByToken, "native")
+
+	rows, err := m.db.Query(`
+		SELECT id, symbol, decimals, token_id, summary_min_balance
+		FROM network_tokens
+		WHERE network_id = ? AND token_type = 'erc20' AND active = TRUE
+		ORDER BY symbol
+	`, network.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get erc20 tokens for network %s: %w", network.Name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var token types.NetworkToken
+		var contract sql.NullString
+		if err := rows.Scan(&token.ID, &token.Symbol, &token.Decimals, &contract, &token.SummaryMinBalance); err != nil {
+			continue
+		}
+		if !contract.Valid || contract.String == "" {
+			continue
+		}
+		token.TokenID = contract
+		token.TokenType = "erc20"
+
+		tokenBalance, err := m.networks.GetERC20Balance(network.Name, account.Address, contract.String)
+		m.counters.rpc(err)
+		if err != nil {
+			if rpcUnavailable(err) {
+				return err
+			}
+			log.Printf("    Error checking ERC-20 %s (%s): %v", token.Symbol, contract.String, err)
+			continue
+		}
+		if tokenBalance.Total.Sign() == 0 {
+			continue
+		}
+
+		vlog.Printf("    Found %s balance: %v (contract=%s)", token.Symbol, tokenBalance.Total, contract.String)
+		m.processTokenBalance(account, network, token, tokenBalance, accountBalance,
+			portfolioTotalsByToken, portfolioChangesByToken, "erc20")
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+)
+
+// familySummary rolls the summaries of a derivation family's accounts up
+// into one entry. Balances of the same token on the same network are
+// summed, so moves between members net out. The entry shows the first
+// member's address and is marked muted only when every member is.
+func familySummary(family string, members []discord.AccountSummary) discord.AccountSummary {
+	rolled := discord.AccountSummary{
+		Name:           fmt.Sprintf("%s (%d derived accounts)", family, len(members)),
+		Address:        members[0].Address,
+		AddressType:    members[0].AddressType,
+		TotalsByToken:  make(map[string]*big.Int),
+		ChangesByToken: make(map[string]*big.Int),
+		Muted:          true,
+	}
+
+	byNetwork := make(map[string]*discord.TokenBalance)
+	truncated := make(map[string]bool)
+	for _, member := range members {
+		rolled.Muted = rolled.Muted && member.Muted
+
+		for key, total := range member.TotalsByToken {
+			rolled.TotalsByToken[key] = addInt(rolled.TotalsByToken[key], total)
+		}
+		for key, change := range member.ChangesByToken {
+			rolled.ChangesByToken[key] = addInt(rolled.ChangesByToken[key], change)
+		}
+		for _, network := range member.TruncatedScans {
+			if !truncated[network] {
+				truncated[network] = true
+				rolled.TruncatedScans = append(rolled.TruncatedScans, network)
+			}
+		}
+
+		for _, tb := range member.TokenBalances {
+			key := tb.Network + "|" + tb.Key
+			sum, ok := byNetwork[key]
+			if !ok {
+				copied := *tb
+				copied.Balance = addInt(nil, tb.Balance)
+				copied.Change = addInt(nil, tb.Change)
+				copied.Bonded = addInt(nil, tb.Bonded)
+				copied.Spendable = addInt(nil, tb.Spendable)
+				copied.Locked = addInt(nil, tb.Locked)
+				copied.BondedBySource = nil
+				for source, amount := range tb.BondedBySource {
+					if copied.BondedBySource == nil {
+						copied.BondedBySource = make(map[string]*big.Int)
+					}
+					copied.BondedBySource[source] = addInt(nil, amount)
+				}
+				byNetwork[key] = &copied
+				rolled.TokenBalances = append(rolled.TokenBalances, &copied)
+				continue
+			}
+
+			sum.Balance = addInt(sum.Balance, tb.Balance)
+			sum.Change = addInt(sum.Change, tb.Change)
+			sum.Bonded = addInt(sum.Bonded, tb.Bonded)
+			sum.Spendable = addInt(sum.Spendable, tb.Spendable)
+			sum.Locked = addInt(sum.Locked, tb.Locked)
+			for source, amount := range tb.BondedBySource {
+				if sum.BondedBySource == nil {
+					sum.BondedBySource = make(map[string]*big.Int)
+				}
+				sum.BondedBySource[source] = addInt(sum.BondedBySource[source], amount)
+			}
+			// Members staking to different destinations have no single one
+			if sum.RewardDestination != tb.RewardDestination {
+				sum.RewardDestination = ""
+			}
+			if sum.Frozen == "" {
+				sum.Frozen = tb.Frozen
+			}
+		}
+	}
+
+	return rolled
+}
+
+// addInt returns sum + v as a new value, treating nil as zero. It stays
+// nil when both are nil so optional amounts remain unset.
+func addInt(sum, v *big.Int) *big.Int {
+	if v == nil {
+		return sum
+	}
+	if sum == nil {
+		return new(big.Int).Set(v)
+	}
+	return new(big.Int).Add(sum, v)
+}
@@ -0,0 +1,41 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// checkNativeFreeze alerts when an account's native balance on a network
+// becomes frozen, and logs when it can be moved again
+func (m *Monitor) checkNativeFreeze(account types.Account, network types.Network, balance types.Balance) {
+	key := fmt.Sprintf("%d:%d", account.ID, network.ID)
+	m.freezeMu.Lock()
+	previous := m.freezes[key]
+	m.freezes[key] = balance.FreezeReason
+	m.freezeMu.Unlock()
+
+	if balance.FreezeReason == previous {
+		return
+	}
+	if balance.FreezeReason == "" {
+		log.Printf("  Native balance of %s on %s is no longer frozen", account.Address, network.Name)
+		return
+	}
+
+	free := "0"
+	if balance.Free != nil {
+		free = discord.FormatTokenAmount(balance.Free, network.Decimals)
+	}
+	message := fmt.Sprintf("The native balance can't be moved: %s. Nothing is spendable until it lifts (%s %s free).",
+		balance.FreezeReason, free, network.Symbol.String)
+
+	log.Printf("  WARNING: %s on %s is frozen: %s", account.Address, network.Name, balance.FreezeReason)
+	if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+		if err := m.discord.SendAccountStateAlert(account.Address, network.Name, "native balance frozen", message); err != nil {
+			log.Printf("Failed to send account state alert: %v", err)
+		}
+	}
+}
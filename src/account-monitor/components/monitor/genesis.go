@@ -0,0 +1,22 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+)
+
+// HandleGenesisMismatch is called by the network manager when a network's
+// endpoint serves a different chain than the one recorded for it. The
+// endpoint is refused until it is fixed or genesis_hash is cleared.
+func (m *Monitor) HandleGenesisMismatch(networkName, expected, actual string) {
+	if !m.config.Load().EnableNotifications {
+		return
+	}
+
+	message := fmt.Sprintf("The endpoint for %s serves a chain with genesis %s, but %s was recorded for it. "+
+		"Reads from %s are refused until its rpc_url/ws_url is fixed, or genesis_hash is cleared if the chain was intentionally changed.",
+		networkName, actual, expected, networkName)
+	if err := m.discord.SendOperationalAlert(fmt.Sprintf("Wrong chain behind %s", networkName), message); err != nil {
+		log.Printf("Failed to send genesis mismatch alert: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// Collective pallets whose membership is tracked for monitored accounts
+var collectivePallets = []string{"Council", "TechnicalCommittee"}
+
+// HandleSudoKeyChange is called by discovery when a network's Sudo.Key
+// differs from the recorded one
+func (m *Monitor) HandleSudoKeyChange(networkName, previous, current string) {
+	if !m.config.Load().EnableNotifications {
+		return
+	}
+
+	message := fmt.Sprintf("Sudo key of %s changed from %s to %s.", networkName, previous, current)
+	if current == "" {
+		message = fmt.Sprintf("Sudo key of %s (%s) was removed.", networkName, previous)
+	}
+	if err := m.discord.SendOperationalAlert(fmt.Sprintf("Sudo key changed on %s", networkName), message); err != nil {
+		log.Printf("Failed to send sudo key alert: %v", err)
+	}
+}
+
+// checkCollectives alerts when a monitored account joins or leaves a
+// collective (Council, TechnicalCommittee). The first read of each
+// collective is the baseline.
+func (m *Monitor) checkCollectives(ctx context.Context) {
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	for _, network := range networkList {
+		for _, pallet := range collectivePallets {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			members, err := m.networks.GetCollectiveMembers(network.Name, pallet)
+			if err != nil {
+				log.Printf("Failed to read %s members on %s: %v", pallet, network.Name, err)
+				continue
+			}
+			if members == nil {
+				continue
+			}
+
+			for _, account := range accounts {
+				publicKey, err := networks.PublicKeyHex(account.Address)
+				if err != nil {
+					continue
+				}
+
+				key := fmt.Sprintf("%d:%d:%s", account.ID, network.ID, pallet)
+				member := members[publicKey]
+				previous, seen := m.collectiveMembers[key]
+				m.collectiveMembers[key] = member
+				if !seen || previous == member {
+					continue
+				}
+
+				title := fmt.Sprintf("added to %s", pallet)
+				if !member {
+					title = fmt.Sprintf("removed from %s", pallet)
+				}
+				log.Printf("%s on %s: %s", account.Address, network.Name, title)
+				if m.config.Load().EnableNotifications && account.Notifies(types.AlertGovernance) {
+					message := fmt.Sprintf("This account was %s on %s.", title, network.Name)
+					if err := m.discord.SendAccountStateAlert(account.Address, network.Name, title, message); err != nil {
+						log.Printf("Failed to send account state alert: %v", err)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"log"
+	"math/big"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// recordHistory appends a balance_history row for the account's balance on a
+// network/token, which backs the history API
+func (m *Monitor) recordHistory(account types.Account, network types.Network, token types.NetworkToken,
+	before, after types.Balance, change *big.Int) {
+
+	var balanceID uint64
+	err := m.db.QueryRow(`
+		SELECT id FROM balances
+		WHERE account_id = ? AND network_id = ? AND network_token_id = ?
+	`, account.ID, network.ID, token.ID).Scan(&balanceID)
+	if err != nil {
+		log.Printf("Failed to look up balance row for history: %v", err)
+		return
+	}
+
+	changeType := "no_change"
+	switch change.Sign() {
+	case 1:
+		changeType = "increase"
+	case -1:
+		changeType = "decrease"
+	}
+
+	err = m.db.RecordBalanceChange(types.BalanceChange{
+		BalanceID:    balanceID,
+		AccountID:    account.ID,
+		NetworkID:    network.ID,
+		TokenID:      token.ID,
+		FreeBefore:   before.Free,
+		FreeAfter:    after.Free,
+		TotalBefore:  before.Total,
+		TotalAfter:   after.Total,
+		ChangeAmount: change,
+		ChangeType:   changeType,
+	})
+	if err != nil {
+		log.Printf("Failed to record balance history: %v", err)
+	}
+}
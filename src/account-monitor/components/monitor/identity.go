@@ -0,0 +1,147 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// identitySnapshots holds the last identity read per account and network
+type identitySnapshots map[string]*networks.Identity
+
+// checkIdentities alerts when a monitored account's identity is cleared,
+// its display name changes or a registrar's judgement changes. The first
+// read of each identity is the baseline.
+func (m *Monitor) checkIdentities(ctx context.Context) {
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	for _, network := range networkList {
+		if detected, err := m.db.HasPallet(network.ID, "Identity"); err != nil || !detected {
+			continue
+		}
+
+		for _, account := range accounts {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			identity, err := m.networks.GetIdentity(network.Name, account.Address)
+			if err != nil {
+				log.Printf("Failed to read identity of %s on %s: %v", account.Address, network.Name, err)
+				continue
+			}
+
+			key := fmt.Sprintf("%d:%d", account.ID, network.ID)
+			previous, seen := m.identities[key]
+			m.identities[key] = identity
+			if !seen {
+				continue
+			}
+
+			title, message := identityChange(previous, identity)
+			if title == "" {
+				continue
+			}
+
+			log.Printf("Identity of %s on %s: %s. %s", account.Address, network.Name, title, message)
+			if m.config.Load().EnableNotifications && account.Notifies(types.AlertIdentity) {
+				if err := m.discord.SendIdentityAlert(account.Address, network.Name, title, message); err != nil {
+					log.Printf("Failed to send identity alert: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// identityChange describes how an identity changed between two reads, or
+// returns an empty title when nothing relevant changed
+func identityChange(before, after *networks.Identity) (title, message string) {
+	switch {
+	case before == nil && after == nil:
+		return "", ""
+	case after == nil:
+		return "identity cleared", fmt.Sprintf("Was: %s", identityStatus(before))
+	case before == nil:
+		return "identity set", identityStatus(after)
+	}
+
+	var changes []string
+	if before.Display != after.Display {
+		changes = append(changes, fmt.Sprintf("Display name: %q → %q", before.Display, after.Display))
+	}
+	for _, registrar := range registrars(before, after) {
+		was, is := before.Judgements[registrar], after.Judgements[registrar]
+		if was == is {
+			continue
+		}
+		if was == "" {
+			was = "none"
+		}
+		if is == "" {
+			is = "none"
+		}
+		changes = append(changes, fmt.Sprintf("Registrar #%d: %s → %s", registrar, was, is))
+	}
+	if len(changes) == 0 {
+		return "", ""
+	}
+
+	title = "identity changed"
+	for _, judgement := range after.Judgements {
+		if judgement == "Erroneous" || judgement == "LowQuality" || judgement == "OutOfDate" {
+			title = "judgement needs attention"
+		}
+	}
+	return title, strings.Join(changes, "\n") + "\nNow: " + identityStatus(after)
+}
+
+// identityStatus reports the display name and judgements together
+func identityStatus(identity *networks.Identity) string {
+	display := identity.Display
+	if display == "" {
+		display = "(no display name)"
+	}
+
+	var judgements []string
+	for _, registrar := range registrars(identity) {
+		judgements = append(judgements, fmt.Sprintf("#%d %s", registrar, identity.Judgements[registrar]))
+	}
+	if len(judgements) == 0 {
+		return display + ", no judgements"
+	}
+	return display + ", " + strings.Join(judgements, ", ")
+}
+
+// registrars returns the registrar indexes judging any of the identities,
+// in order
+func registrars(identities ...*networks.Identity) []uint32 {
+	seen := make(map[uint32]bool)
+	var indexes []uint32
+	for _, identity := range identities {
+		for registrar := range identity.Judgements {
+			if !seen[registrar] {
+				seen[registrar] = true
+				indexes = append(indexes, registrar)
+			}
+		}
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
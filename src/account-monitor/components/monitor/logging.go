@@ -0,0 +1,28 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
+)
+
+// debugLog logs only when enabled; it carries the routine lines of the
+// balance cycle so they don't drown warnings and errors
+type debugLog bool
+
+func (d debugLog) Printf(format string, args ...any) {
+	if d {
+		log.Printf(format, args...)
+	}
+}
+
+// accountLog returns the logger for the routine lines of the index-th
+// account: silent unless LogLevel is debug, and then only for every
+// LogSampleEvery-th account
+func (m *Monitor) accountLog(index int) debugLog {
+	cfg := m.config.Load()
+	if cfg.LogLevel != config.LogLevelDebug {
+		return false
+	}
+	return index%max(cfg.LogSampleEvery, 1) == 0
+}
@@ -0,0 +1,31 @@
+package monitor
+
+import (
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/metrics"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// BalanceGauges returns the account_balance_total gauges updated each cycle
+func (m *Monitor) BalanceGauges() *metrics.BalanceGauges {
+	return m.gauges
+}
+
+// setBalanceGauge exports a balance in token units, counting the series
+// refused by the balance_metrics_max_series cap. A cap of 0 disables the
+// gauges.
+func (m *Monitor) setBalanceGauge(account types.Account, network types.Network, token types.NetworkToken, total *big.Int) {
+	limit := m.config.Load().BalanceMetricsMaxSeries
+	if limit <= 0 {
+		return
+	}
+
+	value := new(big.Float).SetInt(total)
+	value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil)))
+	units, _ := value.Float64()
+
+	if !m.gauges.Set(account.Address, network.Name, token.Symbol, units, limit) {
+		m.counters.gaugesDropped.Add(1)
+	}
+}
@@ -3,14 +3,22 @@ package monitor
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"math/big"
+	"math/rand"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/events"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/metrics"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
 	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
 )
@@ -19,7 +27,87 @@ type Monitor struct {
 	db       *database.DB
 	networks *networks.Manager
 	discord  *discord.Client
-	config   *config.Config
+	config   *config.Live
+	events   *events.Bus
+	gauges   *metrics.BalanceGauges
+
+	balanceCycleRunning atomic.Bool
+	lastCycleDuration   atomic.Int64
+	// Balance and delta checks running in the background, joined by Wait
+	cycles sync.WaitGroup
+
+	// Counters for the balance cycle summary, reset each cycle
+	counters       cycleCounters
+	lastCycleStats atomic.Pointer[CycleStats]
+
+	// Pending reward estimates from the last validator check
+	estimatesMu        sync.Mutex
+	validatorEstimates []discord.ValidatorEstimate
+
+	// Last observed collator problems, keyed by account and network, so
+	// alerts fire on transitions only
+	collatorMu     sync.Mutex
+	collatorIssues map[string]collatorIssues
+
+	// Monitored treasuries from the last treasury check, and the spend
+	// period block each network was last warned about
+	treasuryMu      sync.Mutex
+	treasuries      []discord.TreasurySummary
+	treasuryAlerted map[uint]uint32
+
+	// Accounts currently reported stale; only touched by the balance cycle,
+	// which never overlaps itself
+	staleAlerted map[uint]bool
+
+	// Existential deposits by network id, read once for the dust floor
+	edMu                sync.Mutex
+	existentialDeposits map[uint]*big.Int
+
+	// Last reference-state warning by account and network
+	refMu       sync.Mutex
+	refWarnings map[string]string
+
+	// Last native freeze reason by account and network
+	freezeMu sync.Mutex
+	freezes  map[string]string
+
+	// Last DelegatedStaking delegation by account and network
+	delegationMu sync.Mutex
+	delegations  map[string]delegation
+
+	// Significant changes collected for this cycle's digest in digest alert
+	// mode; only touched by the balance cycle
+	digest []discord.DigestEntry
+
+	// Pending proxy announcements already alerted; only touched by the
+	// bounty loop
+	proxyAnnouncements map[string]bool
+
+	// Active network names as of the last balance cycle, nil before the
+	// first; only touched by the balance cycle
+	activeNetworks map[string]bool
+
+	// Last read asset approvals; only touched by the balance cycle
+	approvals approvalSnapshots
+
+	// Scheduler tasks already alerted; only touched by the bounty loop
+	scheduledTasks map[string]bool
+
+	// Last block scanned for large transfers by network ID; only touched
+	// by the bounty loop
+	whaleBlocks map[uint]uint64
+
+	// Last read identity by account and network (nil when none is set);
+	// only touched by the validator loop
+	identities identitySnapshots
+
+	// Last read collective membership by account, network and pallet;
+	// only touched by the validator loop
+	collectiveMembers map[string]bool
+
+	// Last read session keys of validators; only touched by the validator
+	// loop
+	sessionKeys sessionKeySnapshots
 }
 
 type TokenBalance struct {
@@ -28,23 +116,56 @@ type TokenBalance struct {
 	Symbol    string
 	Decimals  uint8
 	Change    *big.Int
-	TokenType string // native, asset, foreign_asset
+	TokenType string // native, asset, foreign_asset, pool_asset, erc20
 }
 
 type AccountBalance struct {
 	Account        types.Account
 	TokenBalances  []*discord.TokenBalance // All balances
-	TotalsByToken  map[string]*big.Int     // symbol -> total across networks
-	ChangesByToken map[string]*big.Int     // symbol -> change across networks
+	TotalsByToken  map[string]*big.Int     // token key -> total across networks
+	ChangesByToken map[string]*big.Int     // token key -> change across networks
+	// Networks where asset scanning stopped at MaxAssetCallsPerAccount
+	TruncatedNetworks []string
 }
 
-func New(db *database.DB, networks *networks.Manager, discord *discord.Client, config *config.Config) *Monitor {
-	return &Monitor{
+// Capacity of the balance event buffer before events are dropped
+const eventBufferSize = 256
+
+func New(db *database.DB, networks *networks.Manager, discord *discord.Client, config *config.Live) *Monitor {
+	m := &Monitor{
 		db:       db,
 		networks: networks,
 		discord:  discord,
 		config:   config,
+		events:   events.NewBus(eventBufferSize),
+		gauges:   metrics.NewBalanceGauges(),
+
+		collatorIssues:  make(map[string]collatorIssues),
+		treasuryAlerted: make(map[uint]uint32),
+		staleAlerted:    make(map[uint]bool),
+
+		existentialDeposits: make(map[uint]*big.Int),
+		refWarnings:         make(map[string]string),
+		freezes:             make(map[string]string),
+		delegations:         make(map[string]delegation),
+		proxyAnnouncements:  make(map[string]bool),
+		scheduledTasks:      make(map[string]bool),
+		whaleBlocks:         make(map[uint]uint64),
+		identities:          make(identitySnapshots),
+		collectiveMembers:   make(map[string]bool),
+		sessionKeys:         make(sessionKeySnapshots),
+		approvals:           make(approvalSnapshots),
 	}
+
+	m.events.Subscribe(m.notifyDiscord)
+	m.events.Subscribe(m.notifyChangeFeed)
+
+	return m
+}
+
+// Events returns the balance event bus so additional sinks can subscribe
+func (m *Monitor) Events() *events.Bus {
+	return m.events
 }
 
 func (m *Monitor) StartBalanceMonitor(ctx context.Context, interval time.Duration) {
@@ -54,30 +175,136 @@ func (m *Monitor) StartBalanceMonitor(ctx context.Context, interval time.Duratio
 		}
 	}()
 
-	// Run immediately
-	m.checkBalances(ctx)
+	if !m.waitForStart(ctx, 0, "Balance") {
+		return
+	}
+	m.runBalanceCycle(ctx)
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	// Between full cycles, recently changed accounts are re-read on the
+	// delta interval; a nil channel never fires when it is disabled
+	var delta <-chan time.Time
+	if minutes := m.config.Load().DeltaCheckIntervalMinutes; minutes > 0 {
+		deltaTicker := time.NewTicker(time.Duration(minutes) * time.Minute)
+		defer deltaTicker.Stop()
+		delta = deltaTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			m.checkBalances(ctx)
+			m.runBalanceCycle(ctx)
+			interval = resetInterval(ticker, interval, time.Duration(m.config.Load().CheckIntervalHours)*time.Hour, "Balance")
+		case <-delta:
+			m.runDeltaCycle(ctx)
 		}
 	}
 }
 
+// resetInterval picks up an interval changed by a settings reload
+func resetInterval(ticker *time.Ticker, current, configured time.Duration, name string) time.Duration {
+	if configured <= 0 || configured == current {
+		return current
+	}
+	log.Printf("%s monitor interval changed from %v to %v", name, current, configured)
+	ticker.Reset(configured)
+	return configured
+}
+
+// waitForStart delays a monitor's first check by the configured startup
+// delay plus random jitter, staggering monitors by slot. It returns false if
+// ctx is canceled while waiting.
+func (m *Monitor) waitForStart(ctx context.Context, slot int, name string) bool {
+	cfg := m.config.Load()
+	delay := time.Duration(cfg.StartupDelaySeconds) * time.Second
+	delay += time.Duration(slot*cfg.StartupStaggerSeconds) * time.Second
+	if cfg.StartupJitterSeconds > 0 {
+		delay += time.Duration(rand.Int63n(int64(cfg.StartupJitterSeconds) * int64(time.Second)))
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	log.Printf("%s monitor starting in %v", name, delay.Round(time.Second))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// runBalanceCycle starts a balance check in the background unless the
+// previous one is still running, and raises an operational alert if a cycle
+// exceeds the configured duration budget. Wait joins the check.
+func (m *Monitor) runBalanceCycle(ctx context.Context) {
+	if !m.balanceCycleRunning.CompareAndSwap(false, true) {
+		log.Println("Previous balance check still running, skipping this cycle")
+		return
+	}
+
+	m.cycles.Add(1)
+	go func() {
+		defer m.cycles.Done()
+		defer m.balanceCycleRunning.Store(false)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Balance check panic recovered: %v", r)
+			}
+		}()
+
+		start := time.Now()
+
+		if minutes := m.config.Load().MaxCycleDurationMinutes; minutes > 0 {
+			budget := time.Duration(minutes) * time.Minute
+			watchdog := time.AfterFunc(budget, func() {
+				log.Printf("WARNING: balance check has been running for more than %v", budget)
+				if err := m.discord.SendOperationalAlert("Slow balance cycle",
+					fmt.Sprintf("Balance check has exceeded its %v budget and is still running. New cycles are skipped until it finishes.", budget)); err != nil {
+					log.Printf("Failed to send operational alert: %v", err)
+				}
+			})
+			defer watchdog.Stop()
+		}
+
+		m.checkBalances(ctx)
+
+		m.lastCycleDuration.Store(int64(time.Since(start)))
+	}()
+}
+
+// Wait blocks until the balance and delta checks already started have
+// finished, including the digest an interrupted check sends. Call it once
+// StartBalanceMonitor has returned, so no new check can start.
+func (m *Monitor) Wait() {
+	m.cycles.Wait()
+}
+
+// LastCycleDuration returns how long the most recent completed balance
+// check took, or zero if none has completed yet
+func (m *Monitor) LastCycleDuration() time.Duration {
+	return time.Duration(m.lastCycleDuration.Load())
+}
+
 func (m *Monitor) checkBalances(ctx context.Context) {
+	cfg := m.config.Load()
 	log.Println("Starting balance check...")
+	start := time.Now()
+	m.counters.reset()
 
 	accounts, err := m.db.GetAccounts()
 	if err != nil {
 		log.Printf("Failed to get accounts: %v", err)
 		return
 	}
+	accounts = dedupeAccounts(accounts)
 	log.Printf("Found %d accounts to monitor", len(accounts))
 
 	networks, err := m.db.GetNetworks()
@@ -86,22 +313,53 @@ func (m *Monitor) checkBalances(ctx context.Context) {
 		return
 	}
 	log.Printf("Found %d networks to check", len(networks))
+	m.reconcileNetworks(networks)
+
+	cycleID, done := m.resumeCycle()
+
+	accountNetworks, err := m.db.GetAccountNetworks()
+	if err != nil {
+		log.Printf("Failed to get account network restrictions, checking all networks: %v", err)
+	}
 
 	// Track all balances for daily summary
 	accountBalances := make(map[uint]*AccountBalance)
 
 	// Track portfolio totals by token
-	portfolioTotalsByToken := make(map[string]*big.Int)  // symbol -> total value
-	portfolioChangesByToken := make(map[string]*big.Int) // symbol -> total change
+	portfolioTotalsByToken := make(map[string]*big.Int)  // token key -> total value
+	portfolioChangesByToken := make(map[string]*big.Int) // token key -> total change
+
+	// Accounts with at least one successful read this cycle
+	checked := make(map[uint]bool)
+
+	// Networks whose endpoint failed this cycle; the rest of their reads are
+	// skipped rather than timing out once per account
+	unavailable := make(map[string]bool)
+
+	// Networks read at least once this cycle, for the summary
+	checkedNetworks := make(map[string]bool)
 
 	processedAccounts := 0
-	for _, account := range accounts {
+	defer func() { m.finishCycle(start, processedAccounts, checkedNetworks) }()
+	for i, account := range accounts {
+		select {
+		case <-ctx.Done():
+			log.Println("Balance check interrupted; the next check resumes where it stopped")
+			// Resumed pairs don't alert again, so send what was collected
+			m.sendDigest()
+			return
+		default:
+		}
+
+		// Routine per-account lines, logged at debug level for a sample of accounts
+		vlog := m.accountLog(i)
+
 		if !account.MonitorEnabled {
-			log.Printf("Skipping disabled account: %s", account.Address)
+			vlog.Printf("Skipping disabled account: %s", account.Address)
 			continue
 		}
 
-		log.Printf("Processing account %s (%s)", account.Name.String, account.Address)
+		vlog.Printf("Processing account %s (%s)", account.Name.String, account.Address)
 
 		accountBalance := &AccountBalance{
 			Account:        account,
@@ -110,49 +368,118 @@ func (m *Monitor) checkBalances(ctx context.Context) {
 			ChangesByToken: make(map[string]*big.Int),
 		}
 
+		// Asset balance RPC calls made for this account this cycle
+		assetCalls := 0
+
 		for _, network := range networks {
-			if !network.Active {
+			if !network.Active || unavailable[network.Name] {
+				continue
+			}
+			if only, ok := accountNetworks[account.ID]; ok && !only[network.ID] {
 				continue
 			}
 
-			// Get native token balance
-			balance, err := m.networks.GetBalance(network.Name, account.Address)
+			// Processed before the cycle was interrupted
+			if done[cyclePair(account, network)] {
+				m.restoreProgress(cycleID, account, network, accountBalance, portfolioTotalsByToken, portfolioChangesByToken)
+				checked[account.ID] = true
+				continue
+			}
+
+			// H160 accounts are read through the network's Ethereum RPC, and
+			// skipped on networks without one
+			if isEVMAccount(account) {
+				if network.EVMRPCURL.String == "" {
+					continue
+				}
+				checkedNetworks[network.Name] = true
+				if err := m.checkEVMBalances(account, network, accountBalance,
+					portfolioTotalsByToken, portfolioChangesByToken, vlog); err != nil {
+					log.Printf("  Failed to get EVM balances for %s on %s: %v", account.Address, network.Name, err)
+					if rpcUnavailable(err) {
+						log.Printf("  Skipping %s for the rest of this cycle", network.Name)
+						unavailable[network.Name] = true
+					}
+					continue
+				}
+				m.markAccountChecked(account, checked)
+				if err := m.db.MarkCycleProgress(cycleID, account.ID, network.ID); err != nil {
+					log.Printf("  Failed to record cycle progress for %s on %s: %v", account.Address, network.Name, err)
+				}
+				continue
+			}
+
+			// Get native token balance; minimal profile accounts only read
+			// System.Account, at the finalized head with retries
+			minimal := account.Profile == types.ProfileMinimal
+			checkedNetworks[network.Name] = true
+			read := m.networks.GetBalance
+			if minimal {
+				read = m.networks.GetNativeBalance
+			}
+			balance, err := read(network.Name, account.Address)
+			m.counters.rpc(err)
 			if err != nil {
 				log.Printf("  Failed to get balance for %s on %s: %v",
 					account.Address, network.Name, err)
+				if rpcUnavailable(err) {
+					log.Printf("  Skipping %s for the rest of this cycle", network.Name)
+					unavailable[network.Name] = true
+				}
 				continue
 			}
 
+			m.markAccountChecked(account, checked)
+
 			if balance.Total != nil && balance.Total.Cmp(big.NewInt(0)) > 0 {
-				log.Printf("  %s balance on %s: %v", network.Symbol.String, network.Name, balance.Total)
+				vlog.Printf("  %s balance on %s: %v", network.Symbol.String, network.Name, balance.Total)
 			}
 
-			// Get native token info
-			var nativeToken types.NetworkToken
-			err = m.db.QueryRow(`
-				SELECT id, symbol, decimals FROM network_tokens 
-				WHERE network_id = ? AND token_type = 'native'
-			`, network.ID).Scan(&nativeToken.ID, &nativeToken.Symbol, &nativeToken.Decimals)
+			m.checkRefState(account, network, balance)
+			if !minimal {
+				m.checkNativeFreeze(account, network, balance)
+				m.checkDelegation(account, network, balance)
+			}
 
+			// Get native token info
+			nativeToken, err := m.nativeToken(network)
 			if err != nil {
 				log.Printf("  Failed to get native token for network %s: %v", network.Name, err)
 				continue
 			}
 
 			// Process native token balance
-			m.processTokenBalance(account, network, nativeToken, balance, accountBalance,
+			nativeBal := m.processTokenBalance(account, network, nativeToken, balance, accountBalance,
 				portfolioTotalsByToken, portfolioChangesByToken, "native")
 
-			// Check ALL asset tokens
-			if network.Name == "polkadot-assethub" || network.Name == "kusama-assethub" {
-				log.Printf("  Checking assets on %s for %s", network.Name, account.Address)
+			// Report where staking rewards go; Staked rewards compound into bonded
+			if nativeBal != nil && !minimal {
+				dest, err := m.networks.GetRewardDestination(network.Name, account.Address)
+				m.counters.rpc(err)
+				if err != nil {
+					log.Printf("  Failed to get reward destination on %s: %v", network.Name, err)
+				} else if dest != "" {
+					nativeBal.RewardDestination = dest
+					if dest == "Staked" {
+						vlog.Printf("  Rewards on %s compound into bonded (%v bonded)", network.Name, balance.Bonded)
+					}
+				}
+			}
 
+			// Check asset tokens for the token types enabled on this network
+			if assetTypes := m.assetTokenTypes(network); len(assetTypes) > 0 && !minimal {
+				vlog.Printf("  Checking assets on %s for %s", network.Name, account.Address)
+
+				args := []interface{}{network.ID}
+				for _, t := range assetTypes {
+					args = append(args, t)
+				}
 				rows, err := m.db.Query(`
-					SELECT id, symbol, decimals, token_id 
+					SELECT id, symbol, decimals, token_id, token_type, summary_min_balance
 					FROM network_tokens 
-					WHERE network_id = ? AND token_type IN ('asset', 'foreign_asset')
+					WHERE network_id = ? AND active = TRUE AND token_type IN (?`+strings.Repeat(", ?", len(assetTypes)-1)+`)
 					ORDER BY token_type, CAST(token_id AS UNSIGNED)
-				`, network.ID)
+				`, args...)
 
 				if err == nil && rows != nil {
 					func() {
@@ -163,27 +490,39 @@ func (m *Monitor) checkBalances(ctx context.Context) {
 						for rows.Next() {
 							var assetToken types.NetworkToken
 							var tokenID sql.NullString
-							if err := rows.Scan(&assetToken.ID, &assetToken.Symbol, &assetToken.Decimals, &tokenID); err != nil {
+							if err := rows.Scan(&assetToken.ID, &assetToken.Symbol, &assetToken.Decimals, &tokenID,
+								&assetToken.TokenType, &assetToken.SummaryMinBalance); err != nil {
 								continue
 							}
 
 							if !tokenID.Valid || tokenID.String == "" {
 								continue
 							}
+							assetToken.TokenID = tokenID
 
+							if cfg.MaxAssetCallsPerAccount > 0 && assetCalls >= cfg.MaxAssetCallsPerAccount {
+								log.Printf("    WARNING: asset scan for %s truncated on %s after %d calls (max_asset_calls_per_account)",
+									account.Address, network.Name, assetCalls)
+								accountBalance.TruncatedNetworks = append(accountBalance.TruncatedNetworks, network.Name)
+								break
+							}
+							assetCalls++
 							checkedAssets++
 
 							// Log every 50th asset to show progress
 							if checkedAssets%50 == 0 {
-								log.Printf("    Checked %d assets so far...", checkedAssets)
+								vlog.Printf("    Checked %d assets so far...", checkedAssets)
 							}
 
 							// Get asset balance
-							assetBalance, err := m.networks.GetAssetBalance(network.Name, account.Address, tokenID.String)
+							assetBalance, err := m.networks.GetAssetBalance(network.Name, account.Address, assetToken.TokenType, tokenID.String)
+							m.counters.rpc(err)
 							if err != nil {
-								// Only log actual errors, not zero balances
-								if !strings.Contains(err.Error(), "not found") {
-									log.Printf("    Error checking asset %s (%s): %v", assetToken.Symbol, tokenID.String, err)
+								log.Printf("    Error checking asset %s (%s): %v", assetToken.Symbol, tokenID.String, err)
+								if rpcUnavailable(err) {
+									log.Printf("    Skipping %s for the rest of this cycle", network.Name)
+									unavailable[network.Name] = true
+									break
 								}
 								continue
 							}
@@ -193,24 +532,30 @@ func (m *Monitor) checkBalances(ctx context.Context) {
 							}
 
 							foundAssets++
-							log.Printf("    Found %s balance: %v (token_id=%s)", assetToken.Symbol, assetBalance.Total, tokenID.String)
+							vlog.Printf("    Found %s balance: %v (token_id=%s)", assetToken.Symbol, assetBalance.Total, tokenID.String)
 
 							// Process asset balance
-							tokenType := "asset"
-							if strings.Contains(assetToken.Symbol, "FA") || tokenID.String[0] > '9' {
-								tokenType = "foreign_asset"
+							tokenBal := m.processTokenBalance(account, network, assetToken, assetBalance, accountBalance,
+								portfolioTotalsByToken, portfolioChangesByToken, assetToken.TokenType)
+							if assetToken.TokenType == "asset" {
+								m.checkAssetApprovals(account, network, assetToken, assetBalance.Total, tokenBal)
 							}
-
-							m.processTokenBalance(account, network, assetToken, assetBalance, accountBalance,
-								portfolioTotalsByToken, portfolioChangesByToken, tokenType)
 						}
 
-						log.Printf("    Checked %d assets total, found %d with non-zero balance", checkedAssets, foundAssets)
+						vlog.Printf("    Checked %d assets total, found %d with non-zero balance", checkedAssets, foundAssets)
 					}()
 				} else {
-					log.Printf("    No assets to check on %s", network.Name)
+					vlog.Printf("    No assets to check on %s", network.Name)
 				}
 			}
+
+			// A network that failed mid-scan is read again if the cycle resumes
+			if unavailable[network.Name] {
+				continue
+			}
+			if err := m.db.MarkCycleProgress(cycleID, account.ID, network.ID); err != nil {
+				log.Printf("  Failed to record cycle progress for %s on %s: %v", account.Address, network.Name, err)
+			}
 		}
 
 		accountBalances[account.ID] = accountBalance
@@ -219,17 +564,84 @@ func (m *Monitor) checkBalances(ctx context.Context) {
 
 	log.Printf("Processed %d accounts, generating summary...", processedAccounts)
 
+	stale := m.staleAccounts(accounts, checked)
+
+	m.sendDigest()
+
 	// Generate and send daily summary
 	if processedAccounts > 0 {
-		m.sendDailySummary(accountBalances, portfolioTotalsByToken, portfolioChangesByToken)
+		revenue := m.collectRevenue(ctx, accounts)
+		m.sendDailySummary(accountBalances, portfolioTotalsByToken, portfolioChangesByToken, stale, revenue,
+			unavailableNetworks(unavailable))
+	}
+
+	if err := m.db.ClearCycleProgress(cycleID); err != nil {
+		log.Printf("Failed to clear balance cycle progress: %v", err)
 	}
 
 	log.Println("Balance check completed")
 }
 
+// unavailableNetworks returns the sorted names of the networks that failed
+// this cycle
+func unavailableNetworks(unavailable map[string]bool) []string {
+	names := make([]string, 0, len(unavailable))
+	for name := range unavailable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// markAccountChecked records the account's first successful read of the
+// cycle
+func (m *Monitor) markAccountChecked(account types.Account, checked map[uint]bool) {
+	if checked[account.ID] {
+		return
+	}
+	if err := m.db.MarkAccountChecked(account.ID); err != nil {
+		log.Printf("  Failed to record check time for %s: %v", account.Address, err)
+	}
+	checked[account.ID] = true
+}
+
+// assetTokenTypes returns the asset token types to scan on a network. The
+// per-network scan_assets/scan_foreign_assets/scan_pool_assets flags take
+// precedence; when unset, a type is scanned if its pallet was detected
+// during discovery.
+func (m *Monitor) assetTokenTypes(network types.Network) []string {
+	var tokenTypes []string
+
+	scans := []struct {
+		flag      sql.NullBool
+		pallet    string
+		tokenType string
+	}{
+		{network.ScanAssets, "Assets", "asset"},
+		{network.ScanForeignAssets, "ForeignAssets", "foreign_asset"},
+		{network.ScanPoolAssets, "PoolAssets", "pool_asset"},
+	}
+
+	for _, scan := range scans {
+		enabled := scan.flag.Bool
+		if !scan.flag.Valid {
+			detected, err := m.db.HasPallet(network.ID, scan.pallet)
+			if err != nil {
+				log.Printf("  Failed to check %s pallet on %s: %v", scan.pallet, network.Name, err)
+			}
+			enabled = detected
+		}
+		if enabled {
+			tokenTypes = append(tokenTypes, scan.tokenType)
+		}
+	}
+
+	return tokenTypes
+}
+
 func (m *Monitor) processTokenBalance(account types.Account, network types.Network,
 	token types.NetworkToken, balance types.Balance, accountBalance *AccountBalance,
-	portfolioTotalsByToken, portfolioChangesByToken map[string]*big.Int, tokenType string) {
+	portfolioTotalsByToken, portfolioChangesByToken map[string]*big.Int, tokenType string) *discord.TokenBalance {
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -237,115 +649,94 @@ func (m *Monitor) processTokenBalance(account types.Account, network types.Netwo
 		}
 	}()
 
-	// Initialize balance values if nil
-	if balance.Free == nil {
-		balance.Free = big.NewInt(0)
-	}
-	if balance.Reserved == nil {
-		balance.Reserved = big.NewInt(0)
-	}
-	if balance.MiscFrozen == nil {
-		balance.MiscFrozen = big.NewInt(0)
-	}
-	if balance.FeeFrozen == nil {
-		balance.FeeFrozen = big.NewInt(0)
-	}
-	if balance.Bonded == nil {
-		balance.Bonded = big.NewInt(0)
-	}
-	if balance.Total == nil {
-		balance.Total = big.NewInt(0)
-	}
+	balance = normalizeBalance(balance)
+	m.setBalanceGauge(account, network, token, balance.Total)
 
 	// Check for balance changes - initialize previousBalance properly
 	previousBalance := types.Balance{
-		Free:       big.NewInt(0),
-		Reserved:   big.NewInt(0),
-		MiscFrozen: big.NewInt(0),
-		FeeFrozen:  big.NewInt(0),
-		Bonded:     big.NewInt(0),
-		Total:      big.NewInt(0),
+		Free:     big.NewInt(0),
+		Reserved: big.NewInt(0),
+		Frozen:   big.NewInt(0),
+		Bonded:   big.NewInt(0),
+		Total:    big.NewInt(0),
 	}
 
 	// Try to get previous balance
-	var prevFree, prevReserved, prevMisc, prevFee, prevBonded, prevTotal string
+	var prevFree, prevReserved, prevFrozen, prevBonded, prevTotal string
 	err := m.db.QueryRow(`
-		SELECT free, reserved, misc_frozen, fee_frozen, bonded, total 
+		SELECT free, reserved, frozen, bonded, total 
 		FROM balances 
 		WHERE account_id = ? AND network_id = ? AND network_token_id = ?
 	`, account.ID, network.ID, token.ID).Scan(
-		&prevFree, &prevReserved, &prevMisc, &prevFee, &prevBonded, &prevTotal,
+		&prevFree, &prevReserved, &prevFrozen, &prevBonded, &prevTotal,
 	)
 
 	balanceExists := err == nil
 
-	// Parse previous balance strings if they exist
+	// Parse previous balance strings if they exist. A stored value that
+	// isn't a number leaves the previous balance unknown: the row is
+	// re-baselined without alerting rather than the whole balance looking
+	// new against zero.
+	previousUnknown := false
 	if balanceExists {
-		if val, ok := new(big.Int).SetString(prevFree, 10); ok && val != nil {
-			previousBalance.Free = val
-		}
-		if val, ok := new(big.Int).SetString(prevReserved, 10); ok && val != nil {
-			previousBalance.Reserved = val
-		}
-		if val, ok := new(big.Int).SetString(prevMisc, 10); ok && val != nil {
-			previousBalance.MiscFrozen = val
-		}
-		if val, ok := new(big.Int).SetString(prevFee, 10); ok && val != nil {
-			previousBalance.FeeFrozen = val
-		}
-		if val, ok := new(big.Int).SetString(prevBonded, 10); ok && val != nil {
-			previousBalance.Bonded = val
-		}
-		if val, ok := new(big.Int).SetString(prevTotal, 10); ok && val != nil {
-			previousBalance.Total = val
+		for _, field := range []struct {
+			name   string
+			stored string
+			into   **big.Int
+		}{
+			{"free", prevFree, &previousBalance.Free},
+			{"reserved", prevReserved, &previousBalance.Reserved},
+			{"frozen", prevFrozen, &previousBalance.Frozen},
+			{"bonded", prevBonded, &previousBalance.Bonded},
+			{"total", prevTotal, &previousBalance.Total},
+		} {
+			val, ok := new(big.Int).SetString(strings.TrimSpace(field.stored), 10)
+			if !ok {
+				log.Printf("WARNING: stored %s balance %q of %s %s on %s is not a number; re-baselining without alerts",
+					field.name, field.stored, account.Address, token.Symbol, network.Name)
+				previousUnknown = true
+				continue
+			}
+			*field.into = val
 		}
 	}
 
 	change := new(big.Int).Sub(balance.Total, previousBalance.Total)
-
-	// Store token balance info using discord.TokenBalance
-	tokenBal := &discord.TokenBalance{
-		Network:   network.Name,
-		Balance:   new(big.Int).Set(balance.Total), // Create copy
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Change:    new(big.Int).Set(change), // Create copy
-		TokenType: tokenType,
+	if previousUnknown {
+		change.SetInt64(0)
 	}
-	accountBalance.TokenBalances = append(accountBalance.TokenBalances, tokenBal)
 
-	// Update totals by token - properly accumulate
-	if accountBalance.TotalsByToken[token.Symbol] == nil {
-		accountBalance.TotalsByToken[token.Symbol] = big.NewInt(0)
-	}
-	accountBalance.TotalsByToken[token.Symbol].Add(accountBalance.TotalsByToken[token.Symbol], balance.Total)
-
-	if accountBalance.ChangesByToken[token.Symbol] == nil {
-		accountBalance.ChangesByToken[token.Symbol] = big.NewInt(0)
-	}
-	accountBalance.ChangesByToken[token.Symbol].Add(accountBalance.ChangesByToken[token.Symbol], change)
+	key := tokenKey(network, token, tokenType)
 
-	// Update portfolio totals - properly accumulate
-	if portfolioTotalsByToken[token.Symbol] == nil {
-		portfolioTotalsByToken[token.Symbol] = big.NewInt(0)
+	// Store token balance info using discord.TokenBalance
+	tokenBal := &discord.TokenBalance{
+		Key:            key,
+		Network:        network.Name,
+		Balance:        new(big.Int).Set(balance.Total), // Create copy
+		Symbol:         token.Symbol,
+		Decimals:       token.Decimals,
+		Change:         new(big.Int).Set(change), // Create copy
+		Bonded:         new(big.Int).Set(balance.Bonded),
+		BondedBySource: balance.BondedBySource,
+		TokenType:      tokenType,
+		Spendable:      balance.Spendable(),
+		Frozen:         balance.FreezeReason,
+		Locked:         new(big.Int).Set(balance.Frozen),
 	}
-	portfolioTotalsByToken[token.Symbol].Add(portfolioTotalsByToken[token.Symbol], balance.Total)
-
-	if portfolioChangesByToken[token.Symbol] == nil {
-		portfolioChangesByToken[token.Symbol] = big.NewInt(0)
+	if tokenType == "native" || !m.belowSummaryMinimum(token, balance.Total) {
+		addTokenBalance(tokenBal, accountBalance, portfolioTotalsByToken, portfolioChangesByToken)
 	}
-	portfolioChangesByToken[token.Symbol].Add(portfolioChangesByToken[token.Symbol], change)
 
 	// Update database
 	if balanceExists {
 		_, err = m.db.Exec(`
 			UPDATE balances SET 
-				free = ?, reserved = ?, misc_frozen = ?, 
-				fee_frozen = ?, bonded = ?, total = ?, 
+				free = ?, reserved = ?, frozen = ?, 
+				bonded = ?, total = ?, 
 				last_updated = NOW()
 			WHERE account_id = ? AND network_id = ? AND network_token_id = ?
 		`, balance.Free.String(), balance.Reserved.String(),
-			balance.MiscFrozen.String(), balance.FeeFrozen.String(),
+			balance.Frozen.String(),
 			balance.Bonded.String(), balance.Total.String(),
 			account.ID, network.ID, token.ID)
 		if err != nil {
@@ -355,51 +746,213 @@ func (m *Monitor) processTokenBalance(account types.Account, network types.Netwo
 		_, err = m.db.Exec(`
 			INSERT INTO balances 
 			(account_id, network_id, network_token_id, free, reserved, 
-			 misc_frozen, fee_frozen, bonded, total)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			 frozen, bonded, total)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		`, account.ID, network.ID, token.ID,
 			balance.Free.String(), balance.Reserved.String(),
-			balance.MiscFrozen.String(), balance.FeeFrozen.String(),
+			balance.Frozen.String(),
 			balance.Bonded.String(), balance.Total.String())
 		if err != nil {
 			log.Printf("Failed to insert balance: %v", err)
 		}
 	}
 
-	// Send notification if significant change
+	// Record history for new balances and changes
+	if !balanceExists || change.Sign() != 0 {
+		m.recordHistory(account, network, token, previousBalance, balance, change)
+	}
+
+	m.checkReservedCleared(account, network, token, tokenType, previousBalance, balance, balanceExists && !previousUnknown)
+
+	// Publish balance events; notification sinks subscribe to the bus
 	if change.Cmp(big.NewInt(0)) != 0 {
-		changeType := "increase"
-		if change.Cmp(big.NewInt(0)) < 0 {
-			changeType = "decrease"
-		}
+		m.counters.balancesChanged.Add(1)
 
-		changeFloat := new(big.Float).SetInt(change)
-		divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil))
-		changeFloat.Quo(changeFloat, divisor)
+		significant := m.isSignificant(previousBalance.Total, change, token.Decimals) &&
+			!m.isDust(network, tokenType, change)
 
-		changeValue, _ := changeFloat.Float64()
-		if changeValue < 0 {
-			changeValue = -changeValue
+		// The first funds seen get their own alert rather than an increase
+		// from zero, whatever the change thresholds
+		funded := previousBalance.Total.Sign() == 0 && balance.Total.Sign() > 0
+		if funded {
+			significant = !m.isDust(network, tokenType, change)
 		}
 
-		if changeValue >= m.config.MinBalanceChangeNotification && account.DiscordNotify {
-			if m.discord != nil {
-				err := m.discord.SendBalanceChangeNotification(
-					account.Address, network.Name, token.Symbol,
-					previousBalance.Total, balance.Total, changeType)
-				if err != nil {
-					log.Printf("Failed to send Discord notification: %v", err)
-				}
+		event := events.Event{
+			Type:        events.BalanceChanged,
+			Account:     account,
+			Network:     network.Name,
+			Symbol:      token.Symbol,
+			Decimals:    token.Decimals,
+			Before:      new(big.Int).Set(previousBalance.Total),
+			After:       new(big.Int).Set(balance.Total),
+			Change:      new(big.Int).Set(change),
+			Significant: significant,
+			Muted:       m.accountMuted(account.ID),
+		}
+		if funded {
+			event.Type = events.Funded
+		} else if significant && m.config.Load().AlertMode == config.AlertModeDigest {
+			event.Digested = true
+			if account.Notifies(types.AlertBalance) && !event.Muted {
+				m.digest = append(m.digest, discord.DigestEntry{
+					Account:  account.Address,
+					Network:  network.Name,
+					Symbol:   token.Symbol,
+					Decimals: token.Decimals,
+					Before:   event.Before,
+					After:    event.After,
+				})
 			}
 		}
+		m.events.Publish(event)
+
+		if tokenType == "native" {
+			// Account emptied out and removed from System.Account
+			if balance.Total.Sign() == 0 && previousBalance.Total.Sign() > 0 {
+				event.Type = events.Reaped
+				event.Significant = true
+				event.Digested = false
+				m.events.Publish(event)
+			}
+
+			// Balance crossed below the configured low-balance threshold
+			if threshold := tokenUnits(m.config.Load().LowBalanceThreshold, token.Decimals); threshold.Sign() > 0 &&
+				previousBalance.Total.Cmp(threshold) >= 0 && balance.Total.Cmp(threshold) < 0 {
+				event.Type = events.LowBalance
+				event.Significant = true
+				event.Digested = false
+				m.events.Publish(event)
+			}
+		}
+	}
+
+	return tokenBal
+}
+
+// checkReservedCleared publishes a ReservedCleared event when a native
+// reserved balance drops to zero while the account still exists (reaping is
+// alerted separately). The total may barely move when the reserve becomes
+// free, so the reserve itself is held to the change thresholds, measured
+// against the previous total.
+func (m *Monitor) checkReservedCleared(account types.Account, network types.Network, token types.NetworkToken,
+	tokenType string, previous, current types.Balance, existed bool) {
+
+	if tokenType != "native" || !existed || previous.Reserved.Sign() <= 0 || current.Reserved.Sign() != 0 ||
+		current.Total.Sign() == 0 {
+		return
+	}
+
+	reserved := new(big.Int).Neg(previous.Reserved)
+	if !m.isSignificant(previous.Total, reserved, token.Decimals) || m.isDust(network, tokenType, reserved) {
+		return
+	}
+
+	log.Printf("  %s on %s: reserved balance of %v cleared", account.Address, network.Name, previous.Reserved)
+	m.events.Publish(events.Event{
+		Type:        events.ReservedCleared,
+		Account:     account,
+		Network:     network.Name,
+		Symbol:      token.Symbol,
+		Decimals:    token.Decimals,
+		Before:      new(big.Int).Set(previous.Reserved),
+		After:       big.NewInt(0),
+		Change:      new(big.Int).Sub(current.Total, previous.Total),
+		Significant: true,
+		Muted:       m.accountMuted(account.ID),
+	})
+}
+
+// belowSummaryMinimum reports whether an asset holding is too small to show
+// in the summary, by the token's own threshold or the global one
+func (m *Monitor) belowSummaryMinimum(token types.NetworkToken, total *big.Int) bool {
+	threshold := m.config.Load().SummaryMinAssetBalance
+	if token.SummaryMinBalance.Valid {
+		threshold = token.SummaryMinBalance.Float64
+	}
+	minimum := tokenUnits(threshold, token.Decimals)
+	return minimum.Sign() > 0 && total.Cmp(minimum) < 0
+}
+
+// addTokenBalance adds a token balance to the account's summary and
+// accumulates it into the account and portfolio totals
+func addTokenBalance(tokenBal *discord.TokenBalance, accountBalance *AccountBalance,
+	portfolioTotalsByToken, portfolioChangesByToken map[string]*big.Int) {
+
+	accountBalance.TokenBalances = append(accountBalance.TokenBalances, tokenBal)
+	key := tokenBal.Key
+
+	// Update totals by token - properly accumulate
+	if accountBalance.TotalsByToken == nil {
+		accountBalance.TotalsByToken = make(map[string]*big.Int)
+	}
+	if accountBalance.ChangesByToken == nil {
+		accountBalance.ChangesByToken = make(map[string]*big.Int)
 	}
+	if accountBalance.TotalsByToken[key] == nil {
+		accountBalance.TotalsByToken[key] = big.NewInt(0)
+	}
+	accountBalance.TotalsByToken[key].Add(accountBalance.TotalsByToken[key], tokenBal.Balance)
+
+	if accountBalance.ChangesByToken[key] == nil {
+		accountBalance.ChangesByToken[key] = big.NewInt(0)
+	}
+	accountBalance.ChangesByToken[key].Add(accountBalance.ChangesByToken[key], tokenBal.Change)
+
+	// Update portfolio totals - properly accumulate
+	if portfolioTotalsByToken[key] == nil {
+		portfolioTotalsByToken[key] = big.NewInt(0)
+	}
+	portfolioTotalsByToken[key].Add(portfolioTotalsByToken[key], tokenBal.Balance)
+
+	if portfolioChangesByToken[key] == nil {
+		portfolioChangesByToken[key] = big.NewInt(0)
+	}
+	portfolioChangesByToken[key].Add(portfolioChangesByToken[key], tokenBal.Change)
+}
+
+// normalizeBalance replaces nil components with zero so accumulation never
+// dereferences a nil big.Int. A nil Total is rebuilt from free + reserved so
+// a partially decoded balance isn't mistaken for an emptied account.
+func normalizeBalance(balance types.Balance) types.Balance {
+	balance.Free = orZero(balance.Free)
+	balance.Reserved = orZero(balance.Reserved)
+	balance.Frozen = orZero(balance.Frozen)
+	balance.Bonded = orZero(balance.Bonded)
+	if balance.Total == nil {
+		balance.Total = new(big.Int).Add(balance.Free, balance.Reserved)
+	}
+	return balance
+}
+
+// orZero returns v, or a new zero value when v is nil
+func orZero(v *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v
+}
+
+// tokenKey identifies a token for portfolio aggregation. Native tokens
+// aggregate by symbol across networks; assets are qualified by network and
+// asset id so unrelated tokens that share a ticker are not summed together.
+func tokenKey(network types.Network, token types.NetworkToken, tokenType string) string {
+	if tokenType == "native" || !token.TokenID.Valid {
+		return token.Symbol
+	}
+	return fmt.Sprintf("%s@%s:%s", token.Symbol, network.Name, token.TokenID.String)
 }
 
 func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 	portfolioTotalsByToken map[string]*big.Int,
-	portfolioChangesByToken map[string]*big.Int) {
+	portfolioChangesByToken map[string]*big.Int,
+	stale []discord.StaleAccount, revenue []discord.RevenueTotal, unavailable []string) {
+	cfg := m.config.Load()
 
 	log.Println("Preparing daily summary...")
+	if len(unavailable) > 0 {
+		log.Printf("Summary is partial, no data from: %s", strings.Join(unavailable, ", "))
+	}
 
 	// Debug: Print portfolio totals
 	for symbol, total := range portfolioTotalsByToken {
@@ -430,18 +983,40 @@ func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 		}
 	}
 
+	// Drop entries a failed read could have left nil so the loops below
+	// never dereference them
+	for id, ab := range accountBalances {
+		if ab == nil {
+			delete(accountBalances, id)
+			continue
+		}
+		balances := ab.TokenBalances[:0]
+		for _, tb := range ab.TokenBalances {
+			if tb != nil {
+				tb.Balance = orZero(tb.Balance)
+				tb.Change = orZero(tb.Change)
+				tb.Bonded = orZero(tb.Bonded)
+				balances = append(balances, tb)
+			}
+		}
+		ab.TokenBalances = balances
+	}
+
 	summary := discord.DailySummary{
 		TotalAccounts:    len(accountBalances),
 		TotalsByToken:    make(map[string]*discord.TokenTotal),
 		AccountSummaries: []discord.AccountSummary{},
 		TokenDecimals:    tokenDecimals,
+
+		IncludeZeroBalances: cfg.IncludeZeroBalances,
+		GroupBy:             cfg.SummaryGroupBy,
 	}
 
 	// Count active networks
 	networksUsed := make(map[string]bool)
 	for _, ab := range accountBalances {
 		for _, tb := range ab.TokenBalances {
-			if tb.Balance != nil && tb.Balance.Cmp(big.NewInt(0)) > 0 {
+			if tb.Balance.Sign() > 0 {
 				networksUsed[tb.Network] = true
 			}
 		}
@@ -465,7 +1040,7 @@ func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 			// Fallback: try to get from any token balance
 			for _, ab := range accountBalances {
 				for _, tb := range ab.TokenBalances {
-					if tb.Symbol == symbol && tb.Decimals > 0 {
+					if tb.Key == symbol && tb.Decimals > 0 {
 						decimals = tb.Decimals
 						break
 					}
@@ -494,7 +1069,9 @@ func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 			symbol, totalCopy, changeCopy, decimals)
 	}
 
-	// Build account summaries
+	// Build account summaries; derivation family members are rolled up
+	// into one entry per family below
+	families := make(map[string][]discord.AccountSummary)
 	for _, ab := range accountBalances {
 		accountName := ab.Account.Name.String
 		if !ab.Account.Name.Valid || ab.Account.Name.String == "" {
@@ -516,20 +1093,48 @@ func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 			}
 		}
 
-		summary.AccountSummaries = append(summary.AccountSummaries, discord.AccountSummary{
+		accountSummary := discord.AccountSummary{
 			Name:           accountName,
 			Address:        ab.Account.Address,
+			AddressType:    ab.Account.AddressType,
 			TokenBalances:  ab.TokenBalances,
 			TotalsByToken:  totalsCopy,
 			ChangesByToken: changesCopy,
-		})
+			TruncatedScans: ab.TruncatedNetworks,
+			Muted:          m.accountMuted(ab.Account.ID),
+		}
+
+		if family := strings.TrimSpace(ab.Account.Family.String); family != "" {
+			families[family] = append(families[family], accountSummary)
+			continue
+		}
+
+		if cfg.DetectXcmTransfers {
+			accountSummary.Transfers = detectCrossChainTransfers(ab.TokenBalances)
+		}
+
+		summary.AccountSummaries = append(summary.AccountSummaries, accountSummary)
 	}
+	for family, members := range families {
+		rolled := familySummary(family, members)
+		if cfg.DetectXcmTransfers {
+			rolled.Transfers = detectCrossChainTransfers(rolled.TokenBalances)
+		}
+		summary.AccountSummaries = append(summary.AccountSummaries, rolled)
+	}
+
+	summary.AccountSummaries, summary.OmittedAccounts = capAccountSummaries(summary.AccountSummaries, cfg.SummaryMaxAccounts)
 
-	// These will be filled by validator/collator/bounty checks
-	summary.ChildBountyRevenue = big.NewInt(0)
-	summary.ValidatorRevenue = big.NewInt(0)
-	summary.CollatorRevenue = big.NewInt(0)
-	summary.StakingRevenue = big.NewInt(0)
+	summary.Revenue = revenue
+	summary.ValidatorEstimates = m.latestValidatorEstimates()
+	summary.Treasuries = m.latestTreasuries()
+	summary.StaleAccounts = stale
+	summary.UnavailableNetworks = unavailable
+
+	// Deliver any summaries that failed on previous cycles first
+	if err := m.discord.ResendPendingSummaries(); err != nil {
+		log.Printf("Failed to resend pending summaries: %v", err)
+	}
 
 	// Send the summary
 	log.Println("Sending daily summary to Discord...")
@@ -542,7 +1147,9 @@ func (m *Monitor) sendDailySummary(accountBalances map[uint]*AccountBalance,
 }
 
 func (m *Monitor) StartValidatorMonitor(ctx context.Context, interval time.Duration) {
-	// Run immediately
+	if !m.waitForStart(ctx, 1, "Validator") {
+		return
+	}
 	m.checkValidators(ctx)
 
 	ticker := time.NewTicker(interval)
@@ -554,19 +1161,20 @@ func (m *Monitor) StartValidatorMonitor(ctx context.Context, interval time.Durat
 			return
 		case <-ticker.C:
 			m.checkValidators(ctx)
+			interval = resetInterval(ticker, interval, time.Duration(m.config.Load().ValidatorCheckIntervalHours)*time.Hour, "Validator")
 		}
 	}
 }
 
-func (m *Monitor) checkValidators(ctx context.Context) {
-	log.Println("Starting validator check...")
-	// TODO: Implement validator checking logic
-	log.Println("Validator check completed")
-}
-
 func (m *Monitor) StartBountyMonitor(ctx context.Context, interval time.Duration) {
-	// Run immediately
+	if !m.waitForStart(ctx, 2, "Bounty") {
+		return
+	}
 	m.checkBounties(ctx)
+	m.checkTreasury(ctx)
+	m.checkProxyAnnouncements(ctx)
+	m.checkScheduledTasks(ctx)
+	m.checkLargeTransfers(ctx)
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -577,12 +1185,45 @@ func (m *Monitor) StartBountyMonitor(ctx context.Context, interval time.Duration
 			return
 		case <-ticker.C:
 			m.checkBounties(ctx)
+			m.checkTreasury(ctx)
+			m.checkProxyAnnouncements(ctx)
+			m.checkScheduledTasks(ctx)
+			m.checkLargeTransfers(ctx)
+			interval = resetInterval(ticker, interval, time.Duration(m.config.Load().BountyCheckIntervalMinutes)*time.Minute, "Bounty")
 		}
 	}
 }
 
-func (m *Monitor) checkBounties(ctx context.Context) {
-	log.Println("Starting bounty check...")
-	// TODO: Implement bounty checking logic
-	log.Println("Bounty check completed")
+// rpcUnavailable reports whether a networks error means the endpoint is
+// down or refused rather than that the value was missing or malformed
+func rpcUnavailable(err error) bool {
+	return errors.Is(err, networks.ErrRPCUnavailable) || errors.Is(err, networks.ErrGenesisMismatch)
+}
+
+// capAccountSummaries orders the account summaries, those with balance
+// changes this cycle first and then by name, and keeps the first max of
+// them. It returns the kept summaries and how many were left out; max <= 0
+// keeps all. Ranking by total value needs token prices to put holdings of
+// different tokens in one unit, and there is no pricing source.
+func capAccountSummaries(summaries []discord.AccountSummary, max int) ([]discord.AccountSummary, int) {
+	changed := func(s discord.AccountSummary) bool {
+		for _, change := range s.ChangesByToken {
+			if change.Sign() != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		ci, cj := changed(summaries[i]), changed(summaries[j])
+		if ci != cj {
+			return ci
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	if max <= 0 || len(summaries) <= max {
+		return summaries, 0
+	}
+	return summaries[:max], len(summaries) - max
 }
@@ -0,0 +1,202 @@
+package monitor
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+func assetToken(symbol, id string) types.NetworkToken {
+	return types.NetworkToken{
+		Symbol:   symbol,
+		TokenID:  sql.NullString{String: id, Valid: true},
+		Decimals: 6,
+	}
+}
+
+func TestTokenKeyCollidingSymbols(t *testing.T) {
+	relay := types.Network{Name: "polkadot"}
+	assetHub := types.Network{Name: "polkadot-assethub"}
+	moonbeam := types.Network{Name: "moonbeam"}
+
+	holdings := []struct {
+		network   types.Network
+		token     types.NetworkToken
+		tokenType string
+		amount    int64
+	}{
+		// The same native token on two networks
+		{relay, types.NetworkToken{Symbol: "DOT", Decimals: 10}, "native", 100},
+		{assetHub, types.NetworkToken{Symbol: "DOT", Decimals: 10}, "native", 50},
+		// Two unrelated assets sharing the USDT ticker
+		{assetHub, assetToken("USDT", "1984"), "asset", 7},
+		{moonbeam, assetToken("USDT", "311091173110107856861649819128533077277"), "foreign_asset", 3},
+	}
+
+	totals := make(map[string]*big.Int)
+	changes := make(map[string]*big.Int)
+	account := &AccountBalance{}
+	for _, h := range holdings {
+		addTokenBalance(&discord.TokenBalance{
+			Key:     tokenKey(h.network, h.token, h.tokenType),
+			Balance: big.NewInt(h.amount),
+			Change:  big.NewInt(0),
+		}, account, totals, changes)
+	}
+
+	if got := totals["DOT"]; got == nil || got.Int64() != 150 {
+		t.Errorf("DOT total = %v, want 150 summed across networks", got)
+	}
+
+	usdt := 0
+	for key, total := range totals {
+		if key == "DOT" {
+			continue
+		}
+		usdt++
+		if total.Int64() != 7 && total.Int64() != 3 {
+			t.Errorf("%s total = %v, want one asset's balance, not a sum", key, total)
+		}
+	}
+	if usdt != 2 {
+		t.Errorf("got %d USDT totals, want 2: %v", usdt, totals)
+	}
+
+	// The same asset on the same network still aggregates
+	a := tokenKey(assetHub, assetToken("USDT", "1984"), "asset")
+	b := tokenKey(assetHub, assetToken("USDT", "1984"), "asset")
+	if a != b {
+		t.Errorf("same asset got keys %q and %q", a, b)
+	}
+}
+
+// fakeDB is a database/sql driver standing in for MySQL. The DSN is the
+// stored free,reserved,frozen,bonded,total of the balance row, or empty for
+// an account seen for the first time; every write succeeds.
+type fakeDB struct{}
+
+func (fakeDB) Open(dsn string) (driver.Conn, error) { return fakeConn{stored: dsn}, nil }
+
+type fakeConn struct{ stored string }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query: query, stored: c.stored}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+type fakeStmt struct{ query, stored string }
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	switch {
+	case strings.Contains(s.query, "SELECT free, reserved") && s.stored != "":
+		var row []driver.Value
+		for _, v := range strings.Split(s.stored, ",") {
+			row = append(row, v)
+		}
+		return &fakeRows{columns: []string{"free", "reserved", "frozen", "bonded", "total"}, rows: [][]driver.Value{row}}, nil
+	case strings.Contains(s.query, "SELECT id FROM balances"):
+		return &fakeRows{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}, nil
+	}
+	return &fakeRows{columns: []string{"value"}}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("fakedb", fakeDB{})
+}
+
+func testMonitor(t *testing.T, stored string) *Monitor {
+	t.Helper()
+	db, err := sql.Open("fakedb", stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return New(&database.DB{DB: db}, nil, nil, config.NewLive(&config.Config{}))
+}
+
+func TestProcessTokenBalanceNilComponents(t *testing.T) {
+	dot := types.NetworkToken{ID: 1, Symbol: "DOT", Decimals: 10}
+	tests := []struct {
+		name      string
+		stored    string
+		tokenType string
+		balance   types.Balance
+		total     int64
+		change    int64
+	}{
+		{name: "empty new balance", tokenType: "native"},
+		{name: "empty new asset", tokenType: "asset"},
+		{name: "free only", tokenType: "native", balance: types.Balance{Free: big.NewInt(100)}, total: 100, change: 100},
+		{name: "free and reserved", tokenType: "native",
+			balance: types.Balance{Free: big.NewInt(70), Reserved: big.NewInt(30)}, total: 100, change: 100},
+		{name: "explicit total kept", tokenType: "native",
+			balance: types.Balance{Free: big.NewInt(70), Total: big.NewInt(120)}, total: 120, change: 120},
+		{name: "emptied account", stored: "100,20,0,0,120", tokenType: "native", change: -120},
+		{name: "reserve only with stored row", stored: "100,20,0,0,120", tokenType: "native",
+			balance: types.Balance{Reserved: big.NewInt(20)}, total: 20, change: -100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testMonitor(t, tt.stored)
+			account := &AccountBalance{}
+			totals := make(map[string]*big.Int)
+			changes := make(map[string]*big.Int)
+
+			tokenBal := m.processTokenBalance(types.Account{ID: 1, Address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"}, types.Network{ID: 1, Name: "polkadot"},
+				dot, tt.balance, account, totals, changes, tt.tokenType)
+			if tokenBal == nil {
+				t.Fatal("processTokenBalance returned nil; it panicked on a nil component")
+			}
+
+			if tokenBal.Balance.Int64() != tt.total || tokenBal.Change.Int64() != tt.change {
+				t.Errorf("got balance %v change %v, want %d %d", tokenBal.Balance, tokenBal.Change, tt.total, tt.change)
+			}
+			if tokenBal.Bonded.Sign() != 0 || tokenBal.Locked.Sign() != 0 {
+				t.Errorf("got bonded %v locked %v, want zero", tokenBal.Bonded, tokenBal.Locked)
+			}
+			if want := orZero(tt.balance.Free).Int64(); tokenBal.Spendable.Int64() != want {
+				t.Errorf("got spendable %v, want %d", tokenBal.Spendable, want)
+			}
+
+			if got := totals[tokenBal.Key]; got == nil || got.Int64() != tt.total {
+				t.Errorf("portfolio total = %v, want %d", got, tt.total)
+			}
+			if got := changes[tokenBal.Key]; got == nil || got.Int64() != tt.change {
+				t.Errorf("portfolio change = %v, want %d", got, tt.change)
+			}
+		})
+	}
+}
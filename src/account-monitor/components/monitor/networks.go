@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// reconcileNetworks compares the active networks with those of the previous
+// balance cycle, logging (and with notify_network_changes, alerting) the
+// networks added and deactivated. Deactivated networks' clients are closed.
+// The first cycle only records the set.
+func (m *Monitor) reconcileNetworks(networkList []types.Network) {
+	current := make(map[string]bool, len(networkList))
+	for _, network := range networkList {
+		if network.Active {
+			current[network.Name] = true
+		}
+	}
+
+	previous := m.activeNetworks
+	m.activeNetworks = current
+	if previous == nil {
+		return
+	}
+
+	var added, removed []string
+	for name := range current {
+		if !previous[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range previous {
+		if !current[name] {
+			removed = append(removed, name)
+			m.networks.EvictClient(name)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	var lines []string
+	if len(added) > 0 {
+		log.Printf("Networks added: %s", strings.Join(added, ", "))
+		lines = append(lines, fmt.Sprintf("Added: %s", strings.Join(added, ", ")))
+	}
+	if len(removed) > 0 {
+		log.Printf("Networks deactivated or removed: %s", strings.Join(removed, ", "))
+		lines = append(lines, fmt.Sprintf("Deactivated or removed: %s", strings.Join(removed, ", ")))
+	}
+
+	if m.config.Load().EnableNotifications && m.config.Load().NotifyNetworkChanges {
+		if err := m.discord.SendOperationalAlert("Monitored networks changed", strings.Join(lines, "\n")); err != nil {
+			log.Printf("Failed to send network change alert: %v", err)
+		}
+	}
+}
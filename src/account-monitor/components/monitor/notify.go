@@ -0,0 +1,244 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/events"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// notifyDiscord is the Discord sink for balance events
+func (m *Monitor) notifyDiscord(e events.Event) {
+	if m.discord == nil || !m.config.Load().EnableNotifications || !e.Significant {
+		return
+	}
+
+	// Reaping and low balance are critical and still fire for muted accounts
+	// unless MuteCriticalAlerts is set
+	critical := e.Type == events.Reaped || e.Type == events.LowBalance
+	if e.Muted && (!critical || m.config.Load().MuteCriticalAlerts) {
+		return
+	}
+
+	var err error
+	switch e.Type {
+	case events.BalanceChanged:
+		if e.Digested || !e.Account.Notifies(types.AlertBalance) {
+			return
+		}
+		changeType := "increase"
+		if e.Change.Sign() < 0 {
+			changeType = "decrease"
+		}
+		err = m.discord.SendBalanceChangeNotification(
+			e.Account.Address, e.Network, e.Symbol, e.Before, e.After, changeType)
+	case events.Funded:
+		if !e.Account.Notifies(types.AlertBalance) {
+			return
+		}
+		err = m.discord.SendAccountFundedAlert(e.Account.Address, e.Network, e.Symbol, e.After, e.Decimals)
+	case events.ReservedCleared:
+		// A cleared reserve is most often a slashed deposit
+		if !e.Account.Notifies(types.AlertSlash) {
+			return
+		}
+		err = m.discord.SendReservedClearedAlert(e.Account.Address, e.Network, e.Symbol, e.Before, e.Change, e.Decimals)
+	case events.Reaped:
+		if !e.Account.Notifies(types.AlertBalance) {
+			return
+		}
+		err = m.discord.SendReapedAlert(e.Account.Address, e.Network, e.Symbol, e.Before, e.Decimals)
+	case events.LowBalance:
+		if !e.Account.Notifies(types.AlertLowBalance) {
+			return
+		}
+		err = m.discord.SendLowBalanceAlert(e.Account.Address, e.Network, e.Symbol, e.After,
+			tokenUnits(m.config.Load().LowBalanceThreshold, e.Decimals), e.Decimals)
+	}
+
+	if err != nil {
+		log.Printf("Failed to send Discord notification: %v", err)
+		return
+	}
+	m.counters.alertsSent.Add(1)
+}
+
+// notifyChangeFeed posts each significant balance change as one compact
+// line to the changes channel, whether or not it was digested
+func (m *Monitor) notifyChangeFeed(e events.Event) {
+	if m.discord == nil || !m.config.Load().EnableNotifications || !e.Significant ||
+		(e.Type != events.BalanceChanged && e.Type != events.Funded) {
+		return
+	}
+	if e.Muted || !e.Account.Notifies(types.AlertBalance) {
+		return
+	}
+
+	if err := m.discord.SendChangeLine(e.Account.Address, e.Network, e.Symbol, e.Change, e.Decimals); err != nil {
+		log.Printf("Failed to send change line: %v", err)
+	}
+}
+
+// FlushNotifications delivers the balance alerts still queued on the event
+// bus, giving up when ctx is done. It is called on shutdown once the event
+// dispatcher has stopped.
+func (m *Monitor) FlushNotifications(ctx context.Context) {
+	if left := m.events.Drain(ctx); left > 0 {
+		log.Printf("WARNING: shutdown timeout reached, %d queued balance events were not delivered", left)
+	}
+}
+
+// sendDigest sends the balance changes collected during the cycle as a
+// single alert. Reaping and low balance alerts are never digested.
+func (m *Monitor) sendDigest() {
+	digest := m.digest
+	m.digest = nil
+	if len(digest) == 0 || !m.config.Load().EnableNotifications {
+		return
+	}
+
+	log.Printf("Sending digest of %d balance changes", len(digest))
+	if err := m.discord.SendBalanceDigest(digest); err != nil {
+		log.Printf("Failed to send balance digest: %v", err)
+		return
+	}
+	m.counters.alertsSent.Add(1)
+}
+
+// tokenUnits converts an amount in whole tokens to plancks, rounding down.
+// The amount is taken as the decimal it is written as (0.1 is exactly a
+// tenth, not the nearest float64), so thresholds stay exact at 18 decimals.
+func tokenUnits(amount float64, decimals uint8) *big.Int {
+	if amount <= 0 {
+		return big.NewInt(0)
+	}
+	exact, ok := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	if !ok {
+		return big.NewInt(0)
+	}
+	exact.Mul(exact, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return new(big.Int).Quo(exact.Num(), exact.Denom())
+}
+
+// isSignificant applies the absolute (whole tokens) and percentage change
+// thresholds. With no percentage threshold only the absolute one applies;
+// otherwise SignificanceMode "both" requires both, anything else either.
+// The absolute threshold is compared in plancks.
+func (m *Monitor) isSignificant(before, change *big.Int, decimals uint8) bool {
+	cfg := m.config.Load()
+	threshold := tokenUnits(cfg.MinBalanceChangeNotification, decimals)
+	absolute := new(big.Int).Abs(change).Cmp(threshold) >= 0
+	if cfg.MinBalanceChangePercent <= 0 {
+		return absolute
+	}
+
+	// Any change from a zero balance is treated as crossing the percentage
+	percent := before.Sign() == 0 && change.Sign() != 0
+	if before.Sign() != 0 {
+		ratio := new(big.Float).Quo(
+			new(big.Float).SetInt(new(big.Int).Abs(change)),
+			new(big.Float).SetInt(new(big.Int).Abs(before)))
+		pct, _ := ratio.Float64()
+		percent = pct*100 >= cfg.MinBalanceChangePercent
+	}
+
+	if cfg.SignificanceMode == config.SignificanceBoth {
+		return absolute && percent
+	}
+	return absolute || percent
+}
+
+// isDust reports whether a change is below the dust floor: the larger of
+// DustFloorPlancks and, for native tokens, DustFloorEDFraction of the
+// existential deposit. Dust changes are stored but never alert.
+func (m *Monitor) isDust(network types.Network, tokenType string, change *big.Int) bool {
+	cfg := m.config.Load()
+	floor := big.NewInt(int64(max(cfg.DustFloorPlancks, 0)))
+
+	if tokenType == "native" && cfg.DustFloorEDFraction > 0 {
+		if ed := m.existentialDeposit(network); ed != nil {
+			edFloor, _ := new(big.Float).Mul(new(big.Float).SetInt(ed), big.NewFloat(cfg.DustFloorEDFraction)).Int(nil)
+			if edFloor.Cmp(floor) > 0 {
+				floor = edFloor
+			}
+		}
+	}
+
+	return new(big.Int).Abs(change).Cmp(floor) < 0
+}
+
+// existentialDeposit returns the network's cached existential deposit, or
+// nil if it can't be read
+func (m *Monitor) existentialDeposit(network types.Network) *big.Int {
+	m.edMu.Lock()
+	defer m.edMu.Unlock()
+
+	if ed, ok := m.existentialDeposits[network.ID]; ok {
+		return ed
+	}
+
+	ed, err := m.networks.GetExistentialDeposit(network.Name)
+	if err != nil {
+		log.Printf("Failed to get existential deposit for %s: %v", network.Name, err)
+		return nil
+	}
+	m.existentialDeposits[network.ID] = ed
+	return ed
+}
+
+// StartNotificationRetry periodically redelivers alerts that previously
+// failed to send
+func (m *Monitor) StartNotificationRetry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.discord.RetryFailedNotifications(); err != nil {
+				log.Printf("Failed to retry notifications: %v", err)
+			}
+			interval = resetInterval(ticker, interval, time.Duration(m.config.Load().NotificationRetryMinutes)*time.Minute, "Notification retry")
+		}
+	}
+}
+
+// StartMuteCleanup periodically clears mutes that have ended, so
+// accounts.muted_until only holds active mutes
+func (m *Monitor) StartMuteCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cleared, err := m.db.ClearExpiredMutes()
+			if err != nil {
+				log.Printf("Failed to clear expired mutes: %v", err)
+			} else if cleared > 0 {
+				log.Printf("Cleared %d expired account mutes", cleared)
+			}
+			interval = resetInterval(ticker, interval, time.Duration(m.config.Load().MuteCleanupHours)*time.Hour, "Mute cleanup")
+		}
+	}
+}
+
+// accountMuted reports whether the account's alerts are silenced with !mute.
+// It reads the database so a mute applies to the rest of a running cycle.
+func (m *Monitor) accountMuted(accountID uint) bool {
+	until, err := m.db.GetAccountMute(accountID)
+	if err != nil {
+		log.Printf("Failed to read mute state of account %d: %v", accountID, err)
+		return false
+	}
+	return until.Valid && until.Time.After(time.Now())
+}
@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// checkProxyAnnouncements alerts once for every new call a proxy delegate
+// announces on behalf of a monitored account. Announcements are how delayed
+// proxies work, so each one means the delegate can execute a call once the
+// delay passes; it runs with the bounty checks so the alert lands well
+// within typical delays.
+func (m *Monitor) checkProxyAnnouncements(ctx context.Context) {
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	pending := make(map[string]bool)
+	for _, network := range networkList {
+		if detected, err := m.db.HasPallet(network.ID, "Proxy"); err != nil || !detected {
+			continue
+		}
+
+		var current uint32
+		for _, account := range accounts {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			announcements, err := m.networks.GetProxyAnnouncements(network.Name, account.Address)
+			if err != nil {
+				log.Printf("Failed to read proxy announcements for %s on %s: %v", account.Address, network.Name, err)
+				// Keep what was seen so a failed read doesn't re-alert
+				prefix := fmt.Sprintf("%d:%d:", account.ID, network.ID)
+				for key := range m.proxyAnnouncements {
+					if strings.HasPrefix(key, prefix) {
+						pending[key] = true
+					}
+				}
+				continue
+			}
+
+			for _, a := range announcements {
+				key := fmt.Sprintf("%d:%d:%s:%s:%d", account.ID, network.ID, a.Delegate, a.CallHash, a.AnnouncedAt)
+				pending[key] = true
+				if m.proxyAnnouncements[key] {
+					continue
+				}
+
+				if current == 0 {
+					if block, err := m.networks.GetBlockNumber(network.Name); err == nil {
+						current = uint32(block)
+					}
+				}
+
+				log.Printf("Proxy announcement for %s on %s: delegate %s, call %s, executable at block %d",
+					account.Address, network.Name, a.Delegate, a.CallHash, a.ExecutableAt)
+				if m.config.Load().EnableNotifications && account.Notifies(types.AlertProxy) {
+					if err := m.discord.SendProxyAnnouncementAlert(account.Address, network.Name, a.Delegate,
+						a.CallHash, a.ExecutableAt, current); err != nil {
+						log.Printf("Failed to send proxy announcement alert: %v", err)
+					}
+				}
+			}
+		}
+	}
+
+	// Executed, rejected and removed announcements are forgotten
+	m.proxyAnnouncements = pending
+}
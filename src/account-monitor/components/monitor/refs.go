@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// refStateWarning describes an unusual System.Account reference state, or
+// returns "" when the counters look normal
+func refStateWarning(balance types.Balance) string {
+	if balance.Providers > 0 {
+		return ""
+	}
+	switch {
+	case balance.Sufficients == 0 && balance.Consumers > 0:
+		return fmt.Sprintf("No providers but %d consumer reference(s): the account can't be reaped and transfers may fail",
+			balance.Consumers)
+	case balance.Sufficients > 0:
+		return fmt.Sprintf("Kept alive only by %d sufficient asset(s): the account is cleaned up once they are gone",
+			balance.Sufficients)
+	}
+	return ""
+}
+
+// checkRefState alerts when an account enters an unusual provider/consumer
+// state on a network. It is not part of the summary; only the alert and log
+// carry it.
+func (m *Monitor) checkRefState(account types.Account, network types.Network, balance types.Balance) {
+	warning := refStateWarning(balance)
+
+	key := fmt.Sprintf("%d:%d", account.ID, network.ID)
+	m.refMu.Lock()
+	previous := m.refWarnings[key]
+	m.refWarnings[key] = warning
+	m.refMu.Unlock()
+
+	if warning == previous {
+		return
+	}
+	if warning == "" {
+		log.Printf("  Reference state for %s on %s is normal again", account.Address, network.Name)
+		return
+	}
+
+	log.Printf("  WARNING: %s on %s (providers=%d consumers=%d sufficients=%d): %s", account.Address, network.Name,
+		balance.Providers, balance.Consumers, balance.Sufficients, warning)
+	if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+		if err := m.discord.SendAccountStateAlert(account.Address, network.Name, "reference counters", warning); err != nil {
+			log.Printf("Failed to send account state alert: %v", err)
+		}
+	}
+}
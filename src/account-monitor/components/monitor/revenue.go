@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// collectRevenue sums the rewards monitored accounts received since the last
+// scan, read from the payout events of each network's blocks. Staking and
+// parachain staking rewards count as validator or collator revenue when the
+// account holds that role on the network. The last scanned block is kept in
+// networks.last_checked_block so blocks are never counted twice. XCM asset
+// traps found in the same blocks are alerted on.
+func (m *Monitor) collectRevenue(ctx context.Context, accounts []types.Account) []discord.RevenueTotal {
+	cfg := m.config.Load()
+	if cfg.RewardScanMaxBlocks <= 0 {
+		return nil
+	}
+
+	// Monitored accounts by public key
+	watched := make(map[string]bool)
+	accountsByKey := make(map[string]types.Account)
+	for _, account := range accounts {
+		if key, err := networks.PublicKeyHex(account.Address); err == nil {
+			watched[key] = true
+			accountsByKey[key] = account
+		}
+	}
+	if len(watched) == 0 {
+		return nil
+	}
+
+	roles, err := m.activeRoles()
+	if err != nil {
+		log.Printf("Failed to get account roles: %v", err)
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return nil
+	}
+
+	totals := make(map[string]*discord.RevenueTotal)
+	for _, network := range networkList {
+		var token types.NetworkToken
+		err := m.db.QueryRow(`
+			SELECT symbol, decimals FROM network_tokens
+			WHERE network_id = ? AND token_type = 'native'
+		`, network.ID).Scan(&token.Symbol, &token.Decimals)
+		if err != nil {
+			log.Printf("Failed to get native token for network %s: %v", network.Name, err)
+			continue
+		}
+
+		scan, through, err := m.networks.ScanEvents(ctx, network.Name, network.LastCheckedBlock,
+			uint64(cfg.RewardScanMaxBlocks), watched, nil)
+		if err != nil {
+			log.Printf("Reward scan on %s stopped at block %d: %v", network.Name, through, err)
+		}
+		m.alertAssetTraps(network, scan.AssetTraps, accountsByKey)
+		if through > network.LastCheckedBlock {
+			if err := m.db.SetLastCheckedBlock(network.ID, through); err != nil {
+				log.Printf("Failed to record last scanned block on %s: %v", network.Name, err)
+			}
+		}
+
+		for _, reward := range scan.Rewards {
+			total := totals[token.Symbol]
+			if total == nil {
+				total = &discord.RevenueTotal{
+					Symbol:      token.Symbol,
+					Decimals:    token.Decimals,
+					Validator:   big.NewInt(0),
+					Collator:    big.NewInt(0),
+					Staking:     big.NewInt(0),
+					ChildBounty: big.NewInt(0),
+				}
+				totals[token.Symbol] = total
+			}
+
+			role := func(name string) bool {
+				return roles[fmt.Sprintf("%d:%d:%s", accountsByKey[reward.Account].ID, network.ID, name)]
+			}
+			switch {
+			case reward.Kind == networks.RewardChildBounty:
+				total.ChildBounty.Add(total.ChildBounty, reward.Amount)
+			case reward.Kind == networks.RewardStaking && role("validator"):
+				total.Validator.Add(total.Validator, reward.Amount)
+			case reward.Kind == networks.RewardParachainStaking && role("collator"):
+				total.Collator.Add(total.Collator, reward.Amount)
+			default:
+				total.Staking.Add(total.Staking, reward.Amount)
+			}
+		}
+	}
+
+	revenue := make([]discord.RevenueTotal, 0, len(totals))
+	for _, total := range totals {
+		revenue = append(revenue, *total)
+	}
+	sort.Slice(revenue, func(i, j int) bool { return revenue[i].Symbol < revenue[j].Symbol })
+	return revenue
+}
+
+// activeRoles returns the active account roles as
+// "account_id:network_id:role_type"
+func (m *Monitor) activeRoles() (map[string]bool, error) {
+	rows, err := m.db.Query(`
+		SELECT account_id, network_id, role_type FROM account_roles WHERE active = TRUE
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make(map[string]bool)
+	for rows.Next() {
+		var accountID, networkID uint
+		var role string
+		if err := rows.Scan(&accountID, &networkID, &role); err != nil {
+			return nil, err
+		}
+		roles[fmt.Sprintf("%d:%d:%s", accountID, networkID, role)] = true
+	}
+	return roles, rows.Err()
+}
@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// syncAccountRoles detects the validator, nominator and collator roles of
+// every monitored account and keeps account_roles in step, alerting when an
+// account starts or stops a role
+func (m *Monitor) syncAccountRoles(ctx context.Context) {
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+	accounts = dedupeAccounts(accounts)
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	for _, network := range networkList {
+		if !network.Active {
+			continue
+		}
+
+		hasStaking, _ := m.db.HasPallet(network.ID, "Staking")
+		hasCollators, _ := m.db.HasPallet(network.ID, "CollatorSelection")
+		hasParachainStaking, _ := m.db.HasPallet(network.ID, "ParachainStaking")
+		if !hasStaking && !hasCollators && !hasParachainStaking {
+			continue
+		}
+
+		for _, account := range accounts {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			roles, err := m.networks.GetStakingRoles(network.Name, account.Address)
+			if err != nil {
+				log.Printf("Failed to detect roles for %s on %s: %v", account.Address, network.Name, err)
+				continue
+			}
+
+			m.updateRole(account, network, "validator", roles.Validator, roles)
+			m.updateRole(account, network, "nominator", roles.Nominator, roles)
+			m.updateRole(account, network, "collator", roles.Collator, roles)
+		}
+	}
+}
+
+// updateRole upserts one account_roles row and alerts when its active state
+// changes. Accounts that never held the role get no row.
+func (m *Monitor) updateRole(account types.Account, network types.Network, role string, active bool,
+	roles networks.StakingRoles) {
+
+	var wasActive bool
+	err := m.db.QueryRow(`
+		SELECT active FROM account_roles
+		WHERE account_id = ? AND network_id = ? AND role_type = ?
+	`, account.ID, network.ID, role).Scan(&wasActive)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Failed to read %s role for %s: %v", role, account.Address, err)
+		return
+	}
+
+	if !active && !wasActive {
+		return
+	}
+
+	_, err = m.db.Exec(`
+		INSERT INTO account_roles (account_id, network_id, role_type, stash_address, controller_address, active)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+		stash_address = VALUES(stash_address),
+		controller_address = VALUES(controller_address),
+		active = VALUES(active)
+	`, account.ID, network.ID, role, account.Address, nullString(roles.Controller), active)
+	if err != nil {
+		log.Printf("Failed to store %s role for %s: %v", role, account.Address, err)
+		return
+	}
+
+	if wasActive == active {
+		return
+	}
+
+	log.Printf("Account %s %s on %s: active=%v", account.Address, role, network.Name, active)
+	if m.config.Load().EnableNotifications && account.Notifies(types.AlertValidator) {
+		if err := m.discord.SendRoleChangeAlert(account.Address, network.Name, role, active); err != nil {
+			log.Printf("Failed to send role change alert: %v", err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// checkScheduledTasks alerts once for every new Scheduler.Agenda task that
+// references a monitored account. Tasks leave the agenda when dispatched or
+// cancelled and are then forgotten.
+func (m *Monitor) checkScheduledTasks(ctx context.Context) {
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	watched := make(map[string]bool)
+	accountsByKey := make(map[string]types.Account)
+	for _, account := range accounts {
+		if key, err := networks.PublicKeyHex(account.Address); err == nil {
+			watched[key] = true
+			accountsByKey[key] = account
+		}
+	}
+	if len(watched) == 0 {
+		return
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	pending := make(map[string]bool)
+	for _, network := range networkList {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		if detected, err := m.db.HasPallet(network.ID, "Scheduler"); err != nil || !detected {
+			continue
+		}
+
+		tasks, err := m.networks.GetScheduledTasks(ctx, network.Name, watched)
+		if err != nil {
+			log.Printf("Failed to read scheduled tasks on %s: %v", network.Name, err)
+			// Keep what was seen so a failed read doesn't re-alert
+			prefix := fmt.Sprintf("%d:", network.ID)
+			for key := range m.scheduledTasks {
+				if strings.HasPrefix(key, prefix) {
+					pending[key] = true
+				}
+			}
+			continue
+		}
+
+		for _, task := range tasks {
+			account := accountsByKey[task.Account]
+			key := fmt.Sprintf("%d:%d:%d:%d", network.ID, account.ID, task.Block, task.Index)
+			pending[key] = true
+			if m.scheduledTasks[key] {
+				continue
+			}
+
+			log.Printf("Scheduled task for %s on %s: %s at block %d (~%s)",
+				account.Address, network.Name, task.Call, task.Block, task.At.UTC().Format("2006-01-02 15:04 MST"))
+			if m.config.Load().EnableNotifications && account.Notifies(types.AlertBalance) {
+				if err := m.discord.SendScheduledTaskAlert(account.Address, network.Name, task.Call,
+					task.Block, task.At, task.Origin, task.Periodic); err != nil {
+					log.Printf("Failed to send scheduled task alert: %v", err)
+				}
+			}
+		}
+	}
+
+	m.scheduledTasks = pending
+}
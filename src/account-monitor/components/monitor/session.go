@@ -0,0 +1,72 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// sessionKeySnapshots holds the last session keys read per account and
+// network
+type sessionKeySnapshots map[string]networks.SessionKeys
+
+// checkSessionKeys alerts when a validator's queued session keys change or
+// are cleared, and when it leaves or rejoins Session.Validators. The first
+// read of each validator is recorded without alerting.
+func (m *Monitor) checkSessionKeys(account types.Account, network types.Network, stash string) {
+	keys, err := m.networks.GetSessionKeys(network.Name, stash)
+	if err != nil {
+		log.Printf("Failed to read session keys of %s on %s: %v", stash, network.Name, err)
+		return
+	}
+	if keys == nil {
+		return
+	}
+
+	key := fmt.Sprintf("%d:%d", account.ID, network.ID)
+	previous, seen := m.sessionKeys[key]
+	m.sessionKeys[key] = *keys
+	if !seen {
+		return
+	}
+
+	var alerts []discord.ValidatorAlert
+	switch {
+	case previous.NextKeys == keys.NextKeys:
+	case keys.NextKeys == "":
+		alerts = append(alerts, discord.ValidatorAlert{
+			Type:    "session_keys",
+			Message: "Session keys were cleared. The validator goes offline from the next session unless new keys are set.",
+		})
+	case previous.NextKeys == "":
+		alerts = append(alerts, discord.ValidatorAlert{
+			Type:    "session_keys",
+			Message: "Session keys were set and take effect from the next session.",
+		})
+	default:
+		alerts = append(alerts, discord.ValidatorAlert{
+			Type: "session_keys",
+			Message: "Session keys changed and take effect from the next session. " +
+				"Make sure the node was rotated to the new keys, or it will miss blocks.",
+		})
+	}
+	if previous.Active != keys.Active {
+		message := "The validator is no longer in the session's validator set."
+		if keys.Active {
+			message = "The validator is back in the session's validator set."
+		}
+		alerts = append(alerts, discord.ValidatorAlert{Type: "session_set", Message: message})
+	}
+
+	for _, alert := range alerts {
+		log.Printf("Validator %s on %s: %s", stash, network.Name, alert.Message)
+		if m.config.Load().EnableNotifications && account.Notifies(types.AlertValidator) {
+			if err := m.discord.SendValidatorAlert(stash, network.Name, alert); err != nil {
+				log.Printf("Failed to send validator alert: %v", err)
+			}
+		}
+	}
+}
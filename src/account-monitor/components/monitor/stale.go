@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// staleAccounts returns the enabled accounts without a successful read in
+// the last AccountStaleHours, alerting when an account goes stale or
+// recovers. Accounts never checked are measured from their creation.
+func (m *Monitor) staleAccounts(accounts []types.Account, checked map[uint]bool) []discord.StaleAccount {
+	cfg := m.config.Load()
+	if cfg.AccountStaleHours <= 0 {
+		return nil
+	}
+	window := time.Duration(cfg.AccountStaleHours) * time.Hour
+
+	var stale []discord.StaleAccount
+	for _, account := range accounts {
+		if !account.MonitorEnabled {
+			continue
+		}
+
+		isStale := false
+		if !checked[account.ID] {
+			since := account.CreatedAt
+			if account.LastChecked.Valid {
+				since = account.LastChecked.Time
+			}
+			isStale = time.Since(since) > window
+		}
+
+		wasStale := m.staleAlerted[account.ID]
+		m.staleAlerted[account.ID] = isStale
+
+		if isStale {
+			entry := discord.StaleAccount{Name: account.Name.String, Address: account.Address}
+			if account.LastChecked.Valid {
+				entry.LastChecked = account.LastChecked.Time
+			}
+			stale = append(stale, entry)
+		}
+
+		if isStale == wasStale || !cfg.EnableNotifications {
+			continue
+		}
+
+		title, message := "Stale account data", fmt.Sprintf("No successful balance read for %s (%s) in over %v",
+			account.Name.String, account.Address, window)
+		if !isStale {
+			title, message = "Account data fresh again", fmt.Sprintf("Balance reads for %s (%s) are succeeding again",
+				account.Name.String, account.Address)
+		}
+		log.Printf("%s: %s", title, message)
+		if err := m.discord.SendOperationalAlert(title, message); err != nil {
+			log.Printf("Failed to send operational alert: %v", err)
+		}
+	}
+
+	return stale
+}
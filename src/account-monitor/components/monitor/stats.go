@@ -0,0 +1,76 @@
+package monitor
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// CycleStats is the aggregate of one balance cycle
+type CycleStats struct {
+	Accounts        int64
+	Networks        int64
+	RPCCalls        int64
+	RPCFailures     int64
+	BalancesChanged int64
+	AlertsSent      int64
+	Duration        time.Duration
+}
+
+// cycleCounters accumulate CycleStats during a cycle. Alerts are counted
+// by the event sinks on the bus goroutine, so an alert still queued when
+// the cycle ends is counted toward the next one.
+type cycleCounters struct {
+	rpcCalls        atomic.Int64
+	rpcFailures     atomic.Int64
+	balancesChanged atomic.Int64
+	alertsSent      atomic.Int64
+	// Balance series refused by the balance_metrics_max_series cap
+	gaugesDropped atomic.Int64
+}
+
+func (c *cycleCounters) reset() {
+	c.rpcCalls.Store(0)
+	c.rpcFailures.Store(0)
+	c.balancesChanged.Store(0)
+	c.alertsSent.Store(0)
+	c.gaugesDropped.Store(0)
+}
+
+// rpc counts one RPC read and whether it failed
+func (c *cycleCounters) rpc(err error) {
+	c.rpcCalls.Add(1)
+	if err != nil {
+		c.rpcFailures.Add(1)
+	}
+}
+
+// finishCycle logs the one-line cycle summary and keeps it for
+// LastCycleStats
+func (m *Monitor) finishCycle(start time.Time, accounts int, networks map[string]bool) {
+	stats := CycleStats{
+		Accounts:        int64(accounts),
+		Networks:        int64(len(networks)),
+		RPCCalls:        m.counters.rpcCalls.Load(),
+		RPCFailures:     m.counters.rpcFailures.Load(),
+		BalancesChanged: m.counters.balancesChanged.Load(),
+		AlertsSent:      m.counters.alertsSent.Load(),
+		Duration:        time.Since(start),
+	}
+	m.lastCycleStats.Store(&stats)
+
+	if dropped := m.counters.gaugesDropped.Load(); dropped > 0 {
+		log.Printf("WARNING: %d balance series were not exported, balance_metrics_max_series (%d) reached",
+			dropped, m.config.Load().BalanceMetricsMaxSeries)
+	}
+
+	log.Printf("Balance cycle summary: accounts=%d networks=%d rpc_calls=%d rpc_failures=%d balances_changed=%d alerts_sent=%d duration=%v",
+		stats.Accounts, stats.Networks, stats.RPCCalls, stats.RPCFailures,
+		stats.BalancesChanged, stats.AlertsSent, stats.Duration.Round(time.Second))
+}
+
+// LastCycleStats returns the aggregate of the most recent balance cycle,
+// interrupted or not, or nil if none has run yet
+func (m *Monitor) LastCycleStats() *CycleStats {
+	return m.lastCycleStats.Load()
+}
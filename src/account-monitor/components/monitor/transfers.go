@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"math/big"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+)
+
+// Received amounts may be up to this percentage lower than the sent amount
+// to account for XCM delivery and execution fees on the destination.
+const xcmFeeTolerancePercent = 5
+
+// detectCrossChainTransfers pairs a decrease on one network with an increase
+// of the same token on another network within the same cycle. Matching pairs
+// are likely XCM transfers/teleports rather than two unrelated changes.
+func detectCrossChainTransfers(balances []*discord.TokenBalance) []discord.CrossChainTransfer {
+	var transfers []discord.CrossChainTransfer
+	matched := make(map[int]bool)
+
+	for i, out := range balances {
+		if out.Change == nil || out.Change.Sign() >= 0 {
+			continue
+		}
+		sent := new(big.Int).Neg(out.Change)
+
+		for j, in := range balances {
+			if i == j || matched[j] || in.Network == out.Network {
+				continue
+			}
+			if in.Symbol != out.Symbol || in.Decimals != out.Decimals {
+				continue
+			}
+			if in.Change == nil || in.Change.Sign() <= 0 {
+				continue
+			}
+			if !withinFeeTolerance(sent, in.Change) {
+				continue
+			}
+
+			matched[j] = true
+			transfers = append(transfers, discord.CrossChainTransfer{
+				Symbol:      out.Symbol,
+				Decimals:    out.Decimals,
+				FromNetwork: out.Network,
+				ToNetwork:   in.Network,
+				Sent:        new(big.Int).Set(sent),
+				Received:    new(big.Int).Set(in.Change),
+			})
+			break
+		}
+	}
+
+	return transfers
+}
+
+// withinFeeTolerance reports whether received is no more than sent and no
+// less than sent minus xcmFeeTolerancePercent.
+func withinFeeTolerance(sent, received *big.Int) bool {
+	if received.Cmp(sent) > 0 {
+		return false
+	}
+	floor := new(big.Int).Mul(sent, big.NewInt(100-xcmFeeTolerancePercent))
+	return new(big.Int).Mul(received, big.NewInt(100)).Cmp(floor) >= 0
+}
@@ -0,0 +1,120 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// checkTreasury projects the next burn for every monitored treasury account
+// and warns ahead of a burn above TreasuryBurnAlertThreshold. Networks
+// without a Treasury pallet, or whose treasury isn't monitored, are skipped.
+func (m *Monitor) checkTreasury(ctx context.Context) {
+	cfg := m.config.Load()
+	accounts, err := m.db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+		return
+	}
+
+	monitored := make(map[string]types.Account)
+	for _, account := range accounts {
+		if key, err := networks.PublicKeyHex(account.Address); err == nil {
+			monitored[key] = account
+		}
+	}
+
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	var treasuries []discord.TreasurySummary
+	for _, network := range networkList {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if detected, err := m.db.HasPallet(network.ID, "Treasury"); err != nil || !detected {
+			continue
+		}
+
+		status, err := m.networks.GetTreasuryStatus(network.Name)
+		if err != nil {
+			log.Printf("Failed to get treasury status on %s: %v", network.Name, err)
+			continue
+		}
+		if status == nil {
+			continue
+		}
+
+		account, ok := lookupMonitored(monitored, status.Account)
+		if !ok {
+			continue
+		}
+
+		var nativeToken types.NetworkToken
+		err = m.db.QueryRow(`
+			SELECT id, symbol, decimals FROM network_tokens 
+			WHERE network_id = ? AND token_type = 'native'
+		`, network.ID).Scan(&nativeToken.ID, &nativeToken.Symbol, &nativeToken.Decimals)
+		if err != nil {
+			log.Printf("Failed to get native token for network %s: %v", network.Name, err)
+			continue
+		}
+
+		log.Printf("Treasury on %s: pot %v, projected burn %v at block %d",
+			network.Name, status.Pot, status.ProjectedBurn, status.NextSpendBlock)
+
+		treasury := discord.TreasurySummary{
+			Name:           account.Name.String,
+			Address:        account.Address,
+			Network:        network.Name,
+			Symbol:         nativeToken.Symbol,
+			Decimals:       nativeToken.Decimals,
+			Pot:            status.Pot,
+			ProjectedBurn:  status.ProjectedBurn,
+			BurnPermill:    status.BurnPermill,
+			NextSpendBlock: status.NextSpendBlock,
+			NextSpendAt:    status.NextSpendAt,
+		}
+		treasuries = append(treasuries, treasury)
+
+		threshold := tokenUnits(cfg.TreasuryBurnAlertThreshold, nativeToken.Decimals)
+		window := time.Duration(cfg.TreasuryBurnAlertHours) * time.Hour
+		if threshold.Sign() <= 0 || status.ProjectedBurn.Cmp(threshold) < 0 || time.Until(status.NextSpendAt) > window {
+			continue
+		}
+
+		// Warn once per spend period
+		m.treasuryMu.Lock()
+		alerted := m.treasuryAlerted[network.ID] == status.NextSpendBlock
+		m.treasuryAlerted[network.ID] = status.NextSpendBlock
+		m.treasuryMu.Unlock()
+		if alerted || !cfg.EnableNotifications || !account.Notifies(types.AlertTreasury) {
+			continue
+		}
+
+		if err := m.discord.SendTreasuryBurnAlert(treasury); err != nil {
+			log.Printf("Failed to send treasury burn alert: %v", err)
+		}
+	}
+
+	m.treasuryMu.Lock()
+	m.treasuries = treasuries
+	m.treasuryMu.Unlock()
+}
+
+// latestTreasuries returns the treasuries from the last treasury check
+func (m *Monitor) latestTreasuries() []discord.TreasurySummary {
+	m.treasuryMu.Lock()
+	defer m.treasuryMu.Unlock()
+	return m.treasuries
+}
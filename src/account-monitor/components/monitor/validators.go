@@ -0,0 +1,107 @@
+package monitor
+
+import (
+	"context"
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+func (m *Monitor) checkValidators(ctx context.Context) {
+	log.Println("Starting validator check...")
+
+	m.syncAccountRoles(ctx)
+	m.checkCollators(ctx)
+	m.checkIdentities(ctx)
+	m.checkCollectives(ctx)
+
+	rows, err := m.db.Query(`
+		SELECT a.id, a.address, a.name, n.id, n.name, COALESCE(ar.stash_address, a.address),
+		       nt.symbol, nt.decimals
+		FROM account_roles ar
+		JOIN accounts a ON a.id = ar.account_id
+		JOIN networks n ON n.id = ar.network_id
+		JOIN network_tokens nt ON nt.network_id = n.id AND nt.token_type = 'native'
+		WHERE ar.role_type = 'validator' AND ar.active = TRUE AND a.monitor_enabled = TRUE AND n.active = TRUE
+	`)
+	if err != nil {
+		log.Printf("Failed to get validators: %v", err)
+		return
+	}
+
+	type validator struct {
+		account  types.Account
+		network  types.Network
+		stash    string
+		symbol   string
+		decimals uint8
+	}
+	var validators []validator
+	for rows.Next() {
+		var v validator
+		if err := rows.Scan(&v.account.ID, &v.account.Address, &v.account.Name, &v.network.ID, &v.network.Name,
+			&v.stash, &v.symbol, &v.decimals); err != nil {
+			log.Printf("Failed to scan validator: %v", err)
+			continue
+		}
+		validators = append(validators, v)
+	}
+	rows.Close()
+
+	estimates := make([]discord.ValidatorEstimate, 0, len(validators))
+	for _, v := range validators {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		m.checkSessionKeys(v.account, v.network, v.stash)
+
+		estimate, err := m.networks.EstimatePendingReward(v.network.Name, v.stash)
+		if err != nil {
+			log.Printf("Failed to estimate pending rewards for %s on %s: %v", v.stash, v.network.Name, err)
+			continue
+		}
+		if estimate == nil {
+			continue
+		}
+
+		log.Printf("Validator %s on %s: era %d, %d/%d points, estimated pending %v",
+			v.stash, v.network.Name, estimate.Era, estimate.Points, estimate.TotalPoints, estimate.Estimate)
+
+		_, err = m.db.Exec(`
+			INSERT INTO validator_stats (account_id, network_id, era, points)
+			VALUES (?, ?, ?, ?)
+		`, v.account.ID, v.network.ID, estimate.Era, estimate.Points)
+		if err != nil {
+			log.Printf("Failed to store validator stats: %v", err)
+		}
+
+		estimates = append(estimates, discord.ValidatorEstimate{
+			Name:        v.account.Name.String,
+			Address:     v.stash,
+			Network:     v.network.Name,
+			Symbol:      v.symbol,
+			Decimals:    v.decimals,
+			Era:         estimate.Era,
+			Points:      estimate.Points,
+			TotalPoints: estimate.TotalPoints,
+			Estimate:    estimate.Estimate,
+		})
+	}
+
+	m.estimatesMu.Lock()
+	m.validatorEstimates = estimates
+	m.estimatesMu.Unlock()
+
+	log.Println("Validator check completed")
+}
+
+// latestValidatorEstimates returns the estimates from the last validator check
+func (m *Monitor) latestValidatorEstimates() []discord.ValidatorEstimate {
+	m.estimatesMu.Lock()
+	defer m.estimatesMu.Unlock()
+	return m.validatorEstimates
+}
@@ -0,0 +1,81 @@
+package monitor
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// checkLargeTransfers alerts on native transfers of at least a network's
+// whale_transfer_threshold, between any accounts. Each network's blocks are
+// scanned from where the previous check stopped; the first check after
+// startup only reads the head block so a restart doesn't repeat alerts.
+func (m *Monitor) checkLargeTransfers(ctx context.Context) {
+	networkList, err := m.db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+		return
+	}
+
+	for _, network := range networkList {
+		if !network.WhaleTransferThreshold.Valid || strings.TrimSpace(network.WhaleTransferThreshold.String) == "" {
+			delete(m.whaleBlocks, network.ID)
+			continue
+		}
+
+		token, err := m.nativeToken(network)
+		if err != nil {
+			log.Printf("Failed to get native token for network %s: %v", network.Name, err)
+			continue
+		}
+		threshold, ok := parseTokenAmount(network.WhaleTransferThreshold.String, token.Decimals)
+		if !ok || threshold.Sign() <= 0 {
+			log.Printf("WARNING: invalid whale_transfer_threshold %q for %s", network.WhaleTransferThreshold.String, network.Name)
+			continue
+		}
+
+		after, seen := m.whaleBlocks[network.ID]
+		maxBlocks := uint64(max(m.config.Load().RewardScanMaxBlocks, 1))
+		if !seen {
+			maxBlocks = 1
+		}
+		scan, through, err := m.networks.ScanEvents(ctx, network.Name, after, maxBlocks, nil, threshold)
+		if err != nil {
+			log.Printf("Transfer scan on %s stopped at block %d: %v", network.Name, through, err)
+		}
+		if through > after {
+			m.whaleBlocks[network.ID] = through
+		}
+
+		for _, transfer := range scan.Transfers {
+			m.alertLargeTransfer(network, token, transfer, threshold)
+		}
+	}
+}
+
+func (m *Monitor) alertLargeTransfer(network types.Network, token types.NetworkToken, transfer networks.Transfer, threshold *big.Int) {
+	log.Printf("Large transfer on %s at block %d: %v %s from %s to %s",
+		network.Name, transfer.Block, transfer.Amount, token.Symbol, transfer.From, transfer.To)
+	if !m.config.Load().EnableNotifications {
+		return
+	}
+	if err := m.discord.SendLargeTransferAlert(network.Name, token.Symbol, transfer.From, transfer.To,
+		transfer.Amount, threshold, token.Decimals, transfer.Block); err != nil {
+		log.Printf("Failed to send large transfer alert: %v", err)
+	}
+}
+
+// parseTokenAmount converts a decimal amount of whole tokens, e.g. "250000"
+// or "1.5", to plancks
+func parseTokenAmount(s string, decimals uint8) (*big.Int, bool) {
+	amount, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return nil, false
+	}
+	amount.Mul(amount, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return new(big.Int).Quo(amount.Num(), amount.Denom()), true
+}
@@ -0,0 +1,28 @@
+package monitor
+
+import (
+	"log"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// alertAssetTraps alerts on XCM asset traps of monitored accounts found by
+// the event scan. Each block is scanned once, so each trap is alerted once.
+func (m *Monitor) alertAssetTraps(network types.Network, traps []networks.AssetTrap, accountsByKey map[string]types.Account) {
+	for _, trap := range traps {
+		account, ok := accountsByKey[trap.Account]
+		if !ok {
+			continue
+		}
+		log.Printf("WARNING: XCM assets of %s trapped on %s at block %d (trap %s)",
+			account.Address, network.Name, trap.Block, trap.Hash)
+
+		if !m.config.Load().EnableNotifications || !account.Notifies(types.AlertBalance) {
+			continue
+		}
+		if err := m.discord.SendAssetTrapAlert(account.Address, network.Name, trap.Hash, trap.Assets, trap.Block); err != nil {
+			log.Printf("Failed to send asset trap alert: %v", err)
+		}
+	}
+}
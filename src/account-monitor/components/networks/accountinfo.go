@@ -0,0 +1,185 @@
+package networks
+
+import (
+	"fmt"
+	"math/big"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// accountInfo is the part of a System.Account value the monitor uses
+type accountInfo struct {
+	nonce       uint32
+	consumers   uint32
+	providers   uint32
+	sufficients uint32
+	free        *big.Int
+	reserved    *big.Int
+	frozen      *big.Int
+}
+
+// Field names and primitive types of the AccountInfo layout that
+// gstypes.AccountInfo decodes; AccountData's last two fields are either
+// misc_frozen/fee_frozen or frozen/flags
+var (
+	staticAccountInfoFields = []string{"nonce", "consumers", "providers", "sufficients", "data"}
+	staticAccountDataFields = [][]string{
+		{"free", "reserved", "misc_frozen", "fee_frozen"},
+		{"free", "reserved", "frozen", "flags"},
+	}
+)
+
+// accountInfoType returns the type id of the runtime's System.Account value,
+// or false when the metadata has no V14 type information
+func accountInfoType(meta *gstypes.Metadata) (int64, bool) {
+	entry, err := meta.FindStorageEntryMetadata("System", "Account")
+	if err != nil {
+		return 0, false
+	}
+	v14, ok := entry.(gstypes.StorageEntryMetadataV14)
+	if !ok || !v14.Type.IsMap {
+		return 0, false
+	}
+	return v14.Type.AsMap.Value.Int64(), true
+}
+
+// accountInfoStatic reports whether the runtime's AccountInfo has the layout
+// of gstypes.AccountInfo. Runtimes without V14 type information are assumed
+// to.
+func accountInfoStatic(meta *gstypes.Metadata) bool {
+	id, ok := accountInfoType(meta)
+	if !ok {
+		return true
+	}
+	lookup := meta.AsMetadataV14.EfficientLookup
+
+	info := compositeFields(lookup, id)
+	if !fieldsNamed(info, staticAccountInfoFields) {
+		return false
+	}
+	for _, f := range info[:4] {
+		if !primitiveType(lookup, f.Type.Int64(), gstypes.IsU32) {
+			return false
+		}
+	}
+
+	data := compositeFields(lookup, info[4].Type.Int64())
+	for _, names := range staticAccountDataFields {
+		if !fieldsNamed(data, names) {
+			continue
+		}
+		for _, f := range data {
+			if !primitiveType(lookup, f.Type.Int64(), gstypes.IsU128) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compositeFields returns the fields of a composite type, or nil
+func compositeFields(lookup map[int64]*gstypes.Si1Type, id int64) []gstypes.Si1Field {
+	t, ok := lookup[id]
+	if !ok || !t.Def.IsComposite {
+		return nil
+	}
+	return t.Def.Composite.Fields
+}
+
+func fieldsNamed(fields []gstypes.Si1Field, names []string) bool {
+	if len(fields) != len(names) {
+		return false
+	}
+	for i, f := range fields {
+		if !f.HasName || string(f.Name) != names[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// primitiveType reports whether type id is the primitive p, looking through
+// single field wrappers such as ExtraFlags(u128)
+func primitiveType(lookup map[int64]*gstypes.Si1Type, id int64, p gstypes.Si0TypeDefPrimitive) bool {
+	for {
+		t, ok := lookup[id]
+		if !ok {
+			return false
+		}
+		switch {
+		case t.Def.IsPrimitive:
+			return t.Def.Primitive.Si0TypeDefPrimitive == p
+		case t.Def.IsComposite && len(t.Def.Composite.Fields) == 1:
+			id = t.Def.Composite.Fields[0].Type.Int64()
+		default:
+			return false
+		}
+	}
+}
+
+// decodeAccountInfo decodes a System.Account value. The static
+// gstypes.AccountInfo is used when the runtime declares that layout;
+// otherwise the value is decoded with the runtime's own type and the known
+// fields are picked by name, so extra or reordered fields don't misalign
+// the balances.
+func decodeAccountInfo(meta *gstypes.Metadata, raw []byte) (accountInfo, error) {
+	if accountInfoStatic(meta) {
+		var static gstypes.AccountInfo
+		if err := codec.Decode(raw, &static); err != nil {
+			return accountInfo{}, fmt.Errorf("%w: %w", ErrStorageDecode, err)
+		}
+		return accountInfo{
+			nonce:       uint32(static.Nonce),
+			consumers:   uint32(static.Consumers),
+			providers:   uint32(static.Providers),
+			sufficients: uint32(static.Sufficients),
+			free:        static.Data.Free.Int,
+			reserved:    static.Data.Reserved.Int,
+			frozen:      accountFrozen(static.Data.MiscFrozen.Int, static.Data.Flags.Int),
+		}, nil
+	}
+
+	id, _ := accountInfoType(meta)
+	r := &scaleReader{types: meta.AsMetadataV14.EfficientLookup, raw: raw}
+	value, err := r.decode(id)
+	if err != nil {
+		return accountInfo{}, fmt.Errorf("%w: AccountInfo: %w", ErrStorageDecode, err)
+	}
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return accountInfo{}, fmt.Errorf("%w: AccountInfo is not a struct", ErrStorageDecode)
+	}
+	data, ok := fields["data"].(map[string]any)
+	if !ok {
+		return accountInfo{}, fmt.Errorf("%w: AccountInfo has no data field", ErrStorageDecode)
+	}
+	free, ok := data["free"].(*big.Int)
+	if !ok {
+		return accountInfo{}, fmt.Errorf("%w: AccountData has no free balance", ErrStorageDecode)
+	}
+
+	info := accountInfo{
+		nonce:       uint32(bigField(fields, "nonce").Uint64()),
+		consumers:   uint32(bigField(fields, "consumers").Uint64()),
+		providers:   uint32(bigField(fields, "providers").Uint64()),
+		sufficients: uint32(bigField(fields, "sufficients").Uint64()),
+		free:        free,
+		reserved:    bigField(data, "reserved"),
+	}
+	if frozen, ok := data["frozen"].(*big.Int); ok {
+		info.frozen = frozen
+	} else {
+		info.frozen = accountFrozen(bigField(data, "misc_frozen"), bigField(data, "fee_frozen"))
+	}
+	return info, nil
+}
+
+// bigField returns a decoded integer field, or zero when it is missing
+func bigField(fields map[string]any, name string) *big.Int {
+	if value, ok := fields[name].(*big.Int); ok {
+		return value
+	}
+	return big.NewInt(0)
+}
@@ -0,0 +1,107 @@
+package networks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// IsEVMAddress reports whether address is a 20-byte 0x-prefixed hex address
+func IsEVMAddress(address string) bool {
+	address = strings.TrimSpace(address)
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(address[2:])
+	return err == nil
+}
+
+// ValidateAddress checks that address is a 32 or 33 byte hex public key, a
+// 20-byte EVM address or an SS58 address with a valid checksum
+func ValidateAddress(address string) error {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return fmt.Errorf("empty address")
+	}
+
+	if IsEVMAddress(address) {
+		return nil
+	}
+
+	if strings.HasPrefix(address, "0x") || len(address) == 64 {
+		_, err := decodeAddress(address)
+		return err
+	}
+
+	if _, err := decodeSS58Address(address); err != nil {
+		return err
+	}
+
+	decoded, err := base58.Decode(address)
+	if err != nil {
+		return fmt.Errorf("base58 decode failed: %w", err)
+	}
+
+	payload := decoded[:len(decoded)-2]
+	checksum := blake2b.Sum512(append([]byte("SS58PRE"), payload...))
+	if !bytes.Equal(checksum[:2], decoded[len(decoded)-2:]) {
+		return fmt.Errorf("invalid SS58 checksum")
+	}
+
+	return nil
+}
+
+// ValidateFamily checks a list of addresses derived from one seed (//0,
+// //1, ...): each must be valid, no key may appear twice (also under another
+// SS58 prefix) and EVM and substrate addresses can't be mixed. It returns
+// one error per offending address, nil when the list is usable.
+func ValidateFamily(addresses []string) []error {
+	var errs []error
+	seen := make(map[string]string)
+	evm := 0
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if err := ValidateAddress(address); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		key := strings.ToLower(address)
+		if IsEVMAddress(address) {
+			evm++
+		} else if pub, err := PublicKeyHex(address); err == nil {
+			key = pub
+		}
+		if first, ok := seen[key]; ok {
+			errs = append(errs, fmt.Errorf("%s: same account as %s", address, first))
+			continue
+		}
+		seen[key] = address
+	}
+
+	if evm > 0 && evm < len(seen) {
+		errs = append(errs, fmt.Errorf("family mixes %d EVM and %d substrate addresses; one seed derives one kind", evm, len(seen)-evm))
+	}
+	return errs
+}
+
+// DeriveSovereignAccount returns the hex account id of a parachain's
+// sovereign account: on the relay chain when relayChild is set ("para"),
+// otherwise on a sibling parachain ("sibl"). The id is the prefix followed by
+// the little-endian para id, zero padded to 32 bytes.
+func DeriveSovereignAccount(paraID uint32, relayChild bool) string {
+	var accountID [32]byte
+	if relayChild {
+		copy(accountID[:], "para")
+	} else {
+		copy(accountID[:], "sibl")
+	}
+	binary.LittleEndian.PutUint32(accountID[4:8], paraID)
+
+	return "0x" + hex.EncodeToString(accountID[:])
+}
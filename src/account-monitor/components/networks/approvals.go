@@ -0,0 +1,108 @@
+package networks
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+// AssetApproval is an allowance the owner granted a delegate with
+// Assets.approve_transfer: the delegate can move up to Amount of the asset
+// out of the owner's account. Deposit is reserved from the owner's native
+// balance until the approval is used up or cancelled.
+type AssetApproval struct {
+	Delegate string
+	Amount   *big.Int
+	Deposit  *big.Int
+}
+
+// GetAssetApprovals returns the outstanding approvals the account granted
+// for an Assets pallet asset. It returns nil when the network has no Assets
+// pallet.
+func (m *Manager) GetAssetApprovals(networkName, address, assetID string) ([]AssetApproval, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Assets") {
+		return nil, nil
+	}
+
+	owner, err := accountKey(address)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseUint(assetID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid asset ID %s: %w", assetID, err)
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := approvalsPrefix(uint32(id), owner)
+	keys, err := m.storageKeys(context.Background(), api, gstypes.NewStorageKey(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	var approvals []AssetApproval
+	for _, key := range keys {
+		// The delegate follows its own blake2_128 hash
+		if len(key) <= len(prefix)+16 {
+			continue
+		}
+		delegate := key[len(prefix)+16:]
+
+		var raw gstypes.StorageDataRaw
+		ok, err := getStorage(api, key, &raw, at)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		// Approval is {amount: Balance, deposit: DepositBalance}, both u128
+		if len(raw) < 32 {
+			return nil, fmt.Errorf("%w: Assets.Approvals of asset %s: truncated value of %d bytes", ErrStorageDecode, assetID, len(raw))
+		}
+		amount, _ := decodeAssetBalance(raw[:16], false)
+		deposit, _ := decodeAssetBalance(raw[16:32], false)
+
+		approvals = append(approvals, AssetApproval{
+			Delegate: encodeAccount(delegate, network.SS58Prefix),
+			Amount:   amount,
+			Deposit:  deposit,
+		})
+	}
+	return approvals, nil
+}
+
+// approvalsPrefix builds the Assets.Approvals key prefix of an owner's
+// approvals for an asset: prefix + blake2_128(asset_id) + asset_id +
+// blake2_128(owner) + owner
+func approvalsPrefix(assetID uint32, owner []byte) []byte {
+	assetIDBytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(assetIDBytes, assetID)
+
+	key := storagePrefix("Assets", "Approvals", 16+len(assetIDBytes)+16+len(owner))
+	for _, part := range [][]byte{assetIDBytes, owner} {
+		h, _ := blake2b.New(16, nil)
+		h.Write(part)
+		key = h.Sum(key)
+		key = append(key, part...)
+	}
+	return key
+}
@@ -0,0 +1,58 @@
+package networks
+
+import (
+	"fmt"
+	"math/big"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// assetBalanceCompact reports whether pallet's Account storage declares the
+// balance as Compact<Balance> rather than a fixed width integer. Runtimes
+// without V14 type information are assumed to use the fixed width.
+func assetBalanceCompact(meta *gstypes.Metadata, pallet string) bool {
+	entry, err := meta.FindStorageEntryMetadata(pallet, "Account")
+	if err != nil {
+		return false
+	}
+	v14, ok := entry.(gstypes.StorageEntryMetadataV14)
+	if !ok || !v14.Type.IsMap {
+		return false
+	}
+
+	lookup := meta.AsMetadataV14.EfficientLookup
+	value, ok := lookup[v14.Type.AsMap.Value.Int64()]
+	if !ok || !value.Def.IsComposite {
+		return false
+	}
+	for _, f := range value.Def.Composite.Fields {
+		if string(f.Name) != "balance" {
+			continue
+		}
+		t, ok := lookup[f.Type.Int64()]
+		return ok && t.Def.IsCompact
+	}
+	return false
+}
+
+// decodeAssetBalance reads the balance at the start of an AssetAccount
+// value; the status, reason and extra fields that follow are not needed
+func decodeAssetBalance(raw []byte, compact bool) (*big.Int, error) {
+	if compact {
+		value, n := decodeCompactBig(raw)
+		if n == 0 {
+			return nil, fmt.Errorf("invalid compact balance")
+		}
+		return value, nil
+	}
+
+	if len(raw) < 16 {
+		return nil, fmt.Errorf("truncated balance: %d bytes", len(raw))
+	}
+	// Little endian to big endian for big.Int
+	be := make([]byte, 16)
+	for i := 0; i < 16; i++ {
+		be[15-i] = raw[i]
+	}
+	return new(big.Int).SetBytes(be), nil
+}
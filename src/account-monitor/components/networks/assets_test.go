@@ -0,0 +1,134 @@
+package networks
+
+import (
+	"math/big"
+	"testing"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// assetAccountTail stands in for the status, reason and extra fields that
+// follow the balance in an AssetAccount value
+var assetAccountTail = []byte{0x00, 0x01, 0x00}
+
+func TestDecodeAssetBalance(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(63),                // single byte compact
+		big.NewInt(16_383),            // two byte compact
+		big.NewInt(1_073_741_823),     // four byte compact
+		big.NewInt(1_000_000_000_000), // big integer compact
+		big.NewInt(1_000_000_000_000_000_000),
+		maxU128,
+	}
+
+	for _, want := range values {
+		compact, err := codec.Encode(gstypes.NewUCompact(want))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixed, err := codec.Encode(gstypes.NewU128(*want))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tc := range []struct {
+			name    string
+			raw     []byte
+			compact bool
+		}{
+			{"compact", compact, true},
+			{"fixed", fixed, false},
+		} {
+			raw := append(append([]byte{}, tc.raw...), assetAccountTail...)
+			got, err := decodeAssetBalance(raw, tc.compact)
+			if err != nil {
+				t.Errorf("%s %v: %v", tc.name, want, err)
+				continue
+			}
+			if got.Cmp(want) != 0 {
+				t.Errorf("%s %x decoded to %v, want %v", tc.name, tc.raw, got, want)
+			}
+		}
+	}
+}
+
+func TestDecodeAssetBalanceMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []byte
+		compact bool
+	}{
+		{"empty compact", nil, true},
+		{"truncated two byte compact", []byte{0x01}, true},
+		{"truncated four byte compact", []byte{0x02, 0x00}, true},
+		{"truncated big integer compact", []byte{0x07, 0x00, 0x10, 0xa5}, true},
+		{"empty fixed", nil, false},
+		{"truncated fixed", make([]byte, 15), false},
+	}
+	for _, tt := range tests {
+		if got, err := decodeAssetBalance(tt.raw, tt.compact); err == nil {
+			t.Errorf("%s: decoded %v, want an error", tt.name, got)
+		}
+	}
+}
+
+// A fixed width balance must not be read as compact: the same bytes decode
+// to a different value, which is why the layout comes from the metadata
+func TestDecodeAssetBalanceLayoutMatters(t *testing.T) {
+	fixed, _ := codec.Encode(gstypes.NewU128(*big.NewInt(1_000_000)))
+	asCompact, err := decodeAssetBalance(fixed, true)
+	if err == nil && asCompact.Cmp(big.NewInt(1_000_000)) == 0 {
+		t.Errorf("fixed balance %x also decodes as compact %v", fixed, asCompact)
+	}
+}
+
+// assetMetadata builds V14 metadata whose pallet Account storage holds a
+// composite with a balance field, declared compact or as a plain u128
+func assetMetadata(pallet string, compact bool) *gstypes.Metadata {
+	balance := &gstypes.Si1Type{Def: gstypes.Si1TypeDef{IsPrimitive: true, Primitive: gstypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: gstypes.IsU128}}}
+	if compact {
+		balance = &gstypes.Si1Type{Def: gstypes.Si1TypeDef{IsCompact: true, Compact: gstypes.Si1TypeDefCompact{Type: gstypes.NewSi1LookupTypeIDFromUInt(3)}}}
+	}
+	account := &gstypes.Si1Type{Def: gstypes.Si1TypeDef{IsComposite: true, Composite: gstypes.Si1TypeDefComposite{
+		Fields: []gstypes.Si1Field{
+			{HasName: true, Name: "balance", Type: gstypes.NewSi1LookupTypeIDFromUInt(2)},
+			{HasName: true, Name: "status", Type: gstypes.NewSi1LookupTypeIDFromUInt(4)},
+		},
+	}}}
+
+	meta := &gstypes.Metadata{Version: 14}
+	meta.AsMetadataV14.Pallets = []gstypes.PalletMetadataV14{{
+		Name:       gstypes.Text(pallet),
+		HasStorage: true,
+		Storage: gstypes.StorageMetadataV14{
+			Prefix: gstypes.Text(pallet),
+			Items: []gstypes.StorageEntryMetadataV14{{
+				Name: "Account",
+				Type: gstypes.StorageEntryTypeV14{IsMap: true, AsMap: gstypes.MapTypeV14{Value: gstypes.NewSi1LookupTypeIDFromUInt(1)}},
+			}},
+		},
+	}}
+	meta.AsMetadataV14.EfficientLookup = map[int64]*gstypes.Si1Type{1: account, 2: balance}
+	return meta
+}
+
+func TestAssetBalanceCompact(t *testing.T) {
+	for _, pallet := range []string{"Assets", "ForeignAssets", "PoolAssets"} {
+		if !assetBalanceCompact(assetMetadata(pallet, true), pallet) {
+			t.Errorf("%s: compact balance reported as fixed width", pallet)
+		}
+		if assetBalanceCompact(assetMetadata(pallet, false), pallet) {
+			t.Errorf("%s: u128 balance reported as compact", pallet)
+		}
+	}
+
+	if assetBalanceCompact(assetMetadata("Assets", true), "ForeignAssets") {
+		t.Error("pallet missing from the metadata reported as compact")
+	}
+	if assetBalanceCompact(&gstypes.Metadata{Version: 13}, "Assets") {
+		t.Error("metadata without V14 types reported as compact")
+	}
+}
@@ -0,0 +1,159 @@
+package networks
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// bondedSource reads the amount an account has at stake in one staking
+// system, or nil when it has nothing there
+type bondedSource func(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, accountID gstypes.AccountID, at *gstypes.Hash) (*big.Int, error)
+
+// getBonded sums what the account has at stake in every staking system on
+// the network and returns the per-pallet breakdown. The sources never
+// overlap: Staking reads the account's own ledger, pool stake sits in the
+// pool's ledger rather than the member's, and ParachainStaking keeps
+// collator self-bonds and delegations apart.
+func (m *Manager) getBonded(network *types.Network, api *gsrpc.SubstrateAPI, meta *gstypes.Metadata,
+	accountID gstypes.AccountID, at *gstypes.Hash) (*big.Int, map[string]*big.Int) {
+
+	sources := []struct {
+		pallet string
+		read   bondedSource
+	}{
+		{"Staking", m.getStakingBonded},
+		{"NominationPools", m.getPoolBonded},
+		{"ParachainStaking", m.getParachainStakingBonded},
+	}
+
+	total := big.NewInt(0)
+	var bySource map[string]*big.Int
+	for _, source := range sources {
+		if !m.hasPallet(network.ID, source.pallet) {
+			continue
+		}
+		bonded, err := source.read(api, meta, accountID, at)
+		if err != nil {
+			log.Printf("Failed to read %s bonded balance on %s: %v", source.pallet, network.Name, err)
+			continue
+		}
+		if bonded == nil || bonded.Sign() == 0 {
+			continue
+		}
+		if bySource == nil {
+			bySource = make(map[string]*big.Int)
+		}
+		bySource[source.pallet] = bonded
+		total.Add(total, bonded)
+	}
+
+	return total, bySource
+}
+
+// getPoolBonded returns the account's nomination pool stake, including
+// funds still unbonding. Pool points only convert to a balance through the
+// pool's own ledger, so the runtime API does the conversion.
+func (m *Manager) getPoolBonded(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, accountID gstypes.AccountID, at *gstypes.Hash) (*big.Int, error) {
+	key, err := gstypes.CreateStorageKey(meta, "NominationPools", "PoolMembers", accountID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// PoolMember starts with the pool id and the member's points
+	var member struct {
+		PoolID gstypes.U32
+		Points gstypes.U128
+	}
+	ok, err := getStorage(api, key, &member, at)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	if total, err := stateCallU128(api, at, "NominationPoolsApi_member_total_balance", accountID[:]); err == nil {
+		return total, nil
+	}
+
+	// Older runtimes only convert points, which leaves out unbonding funds
+	args := make([]byte, 4, 20)
+	binary.LittleEndian.PutUint32(args, uint32(member.PoolID))
+	points, err := codec.Encode(member.Points)
+	if err != nil {
+		return nil, err
+	}
+	return stateCallU128(api, at, "NominationPoolsApi_points_to_balance", append(args, points...))
+}
+
+// getParachainStakingBonded returns a collator's self bond plus the
+// account's total delegations
+func (m *Manager) getParachainStakingBonded(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, accountID gstypes.AccountID, at *gstypes.Hash) (*big.Int, error) {
+	total := big.NewInt(0)
+
+	// CandidateMetadata starts with the self bond
+	key, err := gstypes.CreateStorageKey(meta, "ParachainStaking", "CandidateInfo", accountID[:])
+	if err != nil {
+		return nil, err
+	}
+	var candidate gstypes.StorageDataRaw
+	if ok, err := getStorage(api, key, &candidate, at); err != nil {
+		return nil, err
+	} else if ok && len(candidate) >= 16 {
+		var bond gstypes.U128
+		if err := codec.Decode(candidate[:16], &bond); err == nil {
+			total.Add(total, bond.Int)
+		}
+	}
+
+	// Delegator is the id, the delegations (owner, amount) and their total
+	key, err = gstypes.CreateStorageKey(meta, "ParachainStaking", "DelegatorState", accountID[:])
+	if err != nil {
+		return nil, err
+	}
+	var delegator gstypes.StorageDataRaw
+	ok, err := getStorage(api, key, &delegator, at)
+	if err != nil || !ok || len(delegator) < len(accountID) {
+		return total, err
+	}
+	raw := []byte(delegator[len(accountID):])
+	count, n := decodeCompact(raw)
+	offset := n + int(count)*(len(accountID)+16)
+	if n == 0 || len(raw) < offset+16 {
+		return total, fmt.Errorf("%w: malformed DelegatorState", ErrStorageDecode)
+	}
+	var delegated gstypes.U128
+	if err := codec.Decode(raw[offset:offset+16], &delegated); err != nil {
+		return total, fmt.Errorf("%w: DelegatorState: %w", ErrStorageDecode, err)
+	}
+
+	return total.Add(total, delegated.Int), nil
+}
+
+// stateCallU128 calls a runtime API that returns a u128
+func stateCallU128(api *gsrpc.SubstrateAPI, at *gstypes.Hash, method string, args []byte) (*big.Int, error) {
+	params := []interface{}{method, "0x" + hex.EncodeToString(args)}
+	if at != nil {
+		params = append(params, at.Hex())
+	}
+
+	var result string
+	if err := api.Client.Call(&result, "state_call", params...); err != nil {
+		return nil, err
+	}
+
+	raw, err := codec.HexDecodeString(result)
+	if err != nil {
+		return nil, err
+	}
+	var value gstypes.U128
+	if err := codec.Decode(raw, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return value.Int, nil
+}
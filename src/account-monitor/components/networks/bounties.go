@@ -0,0 +1,339 @@
+package networks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// BountyInfo is a decoded Bounties.Bounties entry. Addresses are SS58
+// encoded with the network's prefix.
+type BountyInfo struct {
+	ID             uint32
+	Proposer       string
+	Value          *big.Int
+	Fee            *big.Int
+	CuratorDeposit *big.Int
+	Bond           *big.Int
+	Status         string
+	Curator        string
+	Beneficiary    string
+	UpdateDue      uint32
+	UnlockAt       uint32
+}
+
+// ChildBountyInfo is a decoded ChildBounties.ChildBounties entry with the
+// parent bounty's curator resolved for accounting
+type ChildBountyInfo struct {
+	ParentID       uint32
+	ID             uint32
+	Value          *big.Int
+	Fee            *big.Int
+	CuratorDeposit *big.Int
+	Status         string
+	Curator        string
+	Beneficiary    string
+	UnlockAt       uint32
+	ParentCurator  string
+}
+
+// bountyStatus decodes the BountyStatus enum
+type bountyStatus struct {
+	Name        string
+	Curator     *gstypes.AccountID
+	Beneficiary *gstypes.AccountID
+	UpdateDue   uint32
+	UnlockAt    uint32
+}
+
+func (s *bountyStatus) Decode(decoder scale.Decoder) error {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch variant {
+	case 0:
+		s.Name = "proposed"
+	case 1:
+		s.Name = "approved"
+	case 2:
+		s.Name = "funded"
+	case 3:
+		s.Name = "curator_proposed"
+		s.Curator = new(gstypes.AccountID)
+		return decoder.Decode(s.Curator)
+	case 4:
+		s.Name = "active"
+		s.Curator = new(gstypes.AccountID)
+		if err := decoder.Decode(s.Curator); err != nil {
+			return err
+		}
+		return decoder.Decode(&s.UpdateDue)
+	case 5:
+		s.Name = "pending_payout"
+		s.Curator = new(gstypes.AccountID)
+		s.Beneficiary = new(gstypes.AccountID)
+		if err := decoder.Decode(s.Curator); err != nil {
+			return err
+		}
+		if err := decoder.Decode(s.Beneficiary); err != nil {
+			return err
+		}
+		return decoder.Decode(&s.UnlockAt)
+	case 6:
+		s.Name = "approved_with_curator"
+		s.Curator = new(gstypes.AccountID)
+		return decoder.Decode(s.Curator)
+	default:
+		return fmt.Errorf("unknown bounty status variant %d", variant)
+	}
+
+	return nil
+}
+
+// childBountyStatus decodes the ChildBountyStatus enum
+type childBountyStatus struct {
+	Name        string
+	Curator     *gstypes.AccountID
+	Beneficiary *gstypes.AccountID
+	UnlockAt    uint32
+}
+
+func (s *childBountyStatus) Decode(decoder scale.Decoder) error {
+	variant, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch variant {
+	case 0:
+		s.Name = "added"
+	case 1:
+		s.Name = "curator_proposed"
+		s.Curator = new(gstypes.AccountID)
+		return decoder.Decode(s.Curator)
+	case 2:
+		s.Name = "active"
+		s.Curator = new(gstypes.AccountID)
+		return decoder.Decode(s.Curator)
+	case 3:
+		s.Name = "pending_payout"
+		s.Curator = new(gstypes.AccountID)
+		s.Beneficiary = new(gstypes.AccountID)
+		if err := decoder.Decode(s.Curator); err != nil {
+			return err
+		}
+		if err := decoder.Decode(s.Beneficiary); err != nil {
+			return err
+		}
+		return decoder.Decode(&s.UnlockAt)
+	default:
+		return fmt.Errorf("unknown child bounty status variant %d", variant)
+	}
+
+	return nil
+}
+
+// Bounty converts the decoded bounty to its database representation
+func (b *BountyInfo) Bounty(networkID uint) types.Bounty {
+	return types.Bounty{
+		NetworkID:      networkID,
+		BountyID:       uint64(b.ID),
+		Proposer:       sql.NullString{String: b.Proposer, Valid: b.Proposer != ""},
+		Curator:        sql.NullString{String: b.Curator, Valid: b.Curator != ""},
+		Fee:            b.Fee,
+		CuratorDeposit: b.CuratorDeposit,
+		Bond:           b.Bond,
+		Value:          b.Value,
+		Status:         b.Status,
+		Beneficiary:    sql.NullString{String: b.Beneficiary, Valid: b.Beneficiary != ""},
+		UpdateDue:      b.UpdateDue,
+		UnlockAt:       b.UnlockAt,
+	}
+}
+
+// GetBounty reads and decodes Bounties.Bounties(id)
+func (m *Manager) GetBounty(networkName string, bountyID uint32) (*BountyInfo, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Bounties") {
+		return nil, fmt.Errorf("no Bounties pallet on %s", networkName)
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	idBytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(idBytes, bountyID)
+
+	key, err := gstypes.CreateStorageKey(meta, "Bounties", "Bounties", idBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw gstypes.StorageDataRaw
+	ok, err := getStorage(api, key, &raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("bounty %d not found on %s", bountyID, networkName)
+	}
+
+	return decodeBounty(raw, bountyID, network.SS58Prefix)
+}
+
+// decodeBounty decodes a Bounties.Bounties value: the proposer, the value,
+// fee, curator deposit and bond, then the status
+func decodeBounty(raw []byte, bountyID uint32, prefix uint16) (*BountyInfo, error) {
+	var bounty struct {
+		Proposer       gstypes.AccountID
+		Value          gstypes.U128
+		Fee            gstypes.U128
+		CuratorDeposit gstypes.U128
+		Bond           gstypes.U128
+		Status         bountyStatus
+	}
+	if err := codec.Decode(raw, &bounty); err != nil {
+		return nil, fmt.Errorf("%w: bounty %d: %w", ErrStorageDecode, bountyID, err)
+	}
+
+	return &BountyInfo{
+		ID:             bountyID,
+		Proposer:       encodeSS58(bounty.Proposer[:], prefix),
+		Value:          bounty.Value.Int,
+		Fee:            bounty.Fee.Int,
+		CuratorDeposit: bounty.CuratorDeposit.Int,
+		Bond:           bounty.Bond.Int,
+		Status:         bounty.Status.Name,
+		Curator:        encodeOptionalSS58(bounty.Status.Curator, prefix),
+		Beneficiary:    encodeOptionalSS58(bounty.Status.Beneficiary, prefix),
+		UpdateDue:      bounty.Status.UpdateDue,
+		UnlockAt:       bounty.Status.UnlockAt,
+	}, nil
+}
+
+// GetChildBounties returns every child bounty on the network, resolving each
+// parent bounty's curator. It returns nothing when the network has no
+// ChildBounties pallet.
+func (m *Manager) GetChildBounties(networkName string) ([]ChildBountyInfo, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "ChildBounties") {
+		return nil, nil
+	}
+
+	// Key format: prefix(32) + twox64(parent)(8) + parent(4) + twox64(child)(8) + child(4)
+	keys, err := m.storageKeys(context.Background(), api, storagePrefix("ChildBounties", "ChildBounties", 0))
+	if err != nil {
+		return nil, err
+	}
+
+	parentCurators := make(map[uint32]string)
+	var childBounties []ChildBountyInfo
+
+	for _, key := range keys {
+		if len(key) < 56 {
+			continue
+		}
+		parentID := binary.LittleEndian.Uint32(key[40:44])
+		childID := binary.LittleEndian.Uint32(key[52:56])
+
+		var raw gstypes.StorageDataRaw
+		ok, err := getStorage(api, key, &raw, nil)
+		if err != nil || !ok {
+			continue
+		}
+
+		childBounty, err := decodeChildBounty(raw, parentID, childID, network.SS58Prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		parentCurator, resolved := parentCurators[parentID]
+		if !resolved {
+			if parent, err := m.GetBounty(networkName, parentID); err == nil {
+				parentCurator = parent.Curator
+			}
+			parentCurators[parentID] = parentCurator
+		}
+
+		childBounty.ParentCurator = parentCurator
+		childBounties = append(childBounties, *childBounty)
+	}
+
+	return childBounties, nil
+}
+
+// decodeChildBounty decodes a ChildBounties.ChildBounties value: the parent
+// bounty id, the value, fee and curator deposit, then the status
+func decodeChildBounty(raw []byte, parentID, childID uint32, prefix uint16) (*ChildBountyInfo, error) {
+	var childBounty struct {
+		ParentBounty   uint32
+		Value          gstypes.U128
+		Fee            gstypes.U128
+		CuratorDeposit gstypes.U128
+		Status         childBountyStatus
+	}
+	if err := codec.Decode(raw, &childBounty); err != nil {
+		return nil, fmt.Errorf("%w: child bounty %d/%d: %w", ErrStorageDecode, parentID, childID, err)
+	}
+
+	return &ChildBountyInfo{
+		ParentID:       parentID,
+		ID:             childID,
+		Value:          childBounty.Value.Int,
+		Fee:            childBounty.Fee.Int,
+		CuratorDeposit: childBounty.CuratorDeposit.Int,
+		Status:         childBounty.Status.Name,
+		Curator:        encodeOptionalSS58(childBounty.Status.Curator, prefix),
+		Beneficiary:    encodeOptionalSS58(childBounty.Status.Beneficiary, prefix),
+		UnlockAt:       childBounty.Status.UnlockAt,
+	}, nil
+}
+
+// GetBlockNumber returns the best block number
+func (m *Manager) GetBlockNumber(networkName string) (uint64, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+
+	return uint64(header.Number), nil
+}
+
+func encodeOptionalSS58(accountID *gstypes.AccountID, prefix uint16) string {
+	if accountID == nil {
+		return ""
+	}
+	return encodeSS58(accountID[:], prefix)
+}
@@ -0,0 +1,131 @@
+package networks
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Development accounts as SS58 with the generic prefix 42
+const (
+	alice   = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	bob     = "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	charlie = "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y"
+)
+
+// Bounties.Bounties values, SCALE encoded as the runtime stores them:
+// proposer Alice, value 100 DOT, fee 5 DOT, curator deposit 2.5 DOT, bond
+// 1 DOT, then the status with Bob as curator and Charlie as beneficiary
+var bountyBlobs = map[string]string{
+	"proposed":              "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b5402000000000000000000000000",
+	"approved":              "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b5402000000000000000000000001",
+	"funded":                "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b5402000000000000000000000002",
+	"curator_proposed":      "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b54020000000000000000000000038eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48",
+	"active":                "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b54020000000000000000000000048eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48406f4001",
+	"pending_payout":        "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b54020000000000000000000000058eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a4890b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe2200f94101",
+	"approved_with_curator": "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d0010a5d4e8000000000000000000000000743ba40b000000000000000000000000ba1dd205000000000000000000000000e40b54020000000000000000000000068eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48",
+}
+
+// ChildBounties.ChildBounties values: parent 11, value 2 DOT, fee 0.1 DOT,
+// curator deposit 0.05 DOT, then the status
+var childBountyBlobs = map[string]string{
+	"added":            "0b00000000c817a804000000000000000000000000ca9a3b0000000000000000000000000065cd1d00000000000000000000000000",
+	"curator_proposed": "0b00000000c817a804000000000000000000000000ca9a3b0000000000000000000000000065cd1d000000000000000000000000018eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48",
+	"active":           "0b00000000c817a804000000000000000000000000ca9a3b0000000000000000000000000065cd1d000000000000000000000000028eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48",
+	"pending_payout":   "0b00000000c817a804000000000000000000000000ca9a3b0000000000000000000000000065cd1d000000000000000000000000038eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a4890b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe2200f94101",
+}
+
+func TestDecodeBountyStatuses(t *testing.T) {
+	tests := []struct {
+		status      string
+		curator     string
+		beneficiary string
+		updateDue   uint32
+		unlockAt    uint32
+	}{
+		{status: "proposed"},
+		{status: "approved"},
+		{status: "funded"},
+		{status: "curator_proposed", curator: bob},
+		{status: "active", curator: bob, updateDue: 21_000_000},
+		{status: "pending_payout", curator: bob, beneficiary: charlie, unlockAt: 21_100_800},
+		{status: "approved_with_curator", curator: bob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			raw, err := hex.DecodeString(bountyBlobs[tt.status])
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounty, err := decodeBounty(raw, 7, 42)
+			if err != nil {
+				t.Fatalf("decodeBounty: %v", err)
+			}
+
+			if bounty.ID != 7 || bounty.Proposer != alice {
+				t.Errorf("bounty %d proposed by %s, want 7 by %s", bounty.ID, bounty.Proposer, alice)
+			}
+			if bounty.Value.String() != "1000000000000" || bounty.Fee.String() != "50000000000" ||
+				bounty.CuratorDeposit.String() != "25000000000" || bounty.Bond.String() != "10000000000" {
+				t.Errorf("got value %s fee %s curator deposit %s bond %s, want 1000000000000 50000000000 25000000000 10000000000",
+					bounty.Value, bounty.Fee, bounty.CuratorDeposit, bounty.Bond)
+			}
+			if bounty.Status != tt.status || bounty.Curator != tt.curator || bounty.Beneficiary != tt.beneficiary ||
+				bounty.UpdateDue != tt.updateDue || bounty.UnlockAt != tt.unlockAt {
+				t.Errorf("got status %s curator %q beneficiary %q update due %d unlock at %d, want %s %q %q %d %d",
+					bounty.Status, bounty.Curator, bounty.Beneficiary, bounty.UpdateDue, bounty.UnlockAt,
+					tt.status, tt.curator, tt.beneficiary, tt.updateDue, tt.unlockAt)
+			}
+		})
+	}
+}
+
+func TestDecodeChildBountyStatuses(t *testing.T) {
+	tests := []struct {
+		status      string
+		curator     string
+		beneficiary string
+		unlockAt    uint32
+	}{
+		{status: "added"},
+		{status: "curator_proposed", curator: bob},
+		{status: "active", curator: bob},
+		{status: "pending_payout", curator: bob, beneficiary: charlie, unlockAt: 21_100_800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			raw, err := hex.DecodeString(childBountyBlobs[tt.status])
+			if err != nil {
+				t.Fatal(err)
+			}
+			child, err := decodeChildBounty(raw, 11, 3, 42)
+			if err != nil {
+				t.Fatalf("decodeChildBounty: %v", err)
+			}
+
+			if child.ParentID != 11 || child.ID != 3 {
+				t.Errorf("got child bounty %d/%d, want 11/3", child.ParentID, child.ID)
+			}
+			if child.Value.String() != "20000000000" || child.Fee.String() != "1000000000" ||
+				child.CuratorDeposit.String() != "500000000" {
+				t.Errorf("got value %s fee %s curator deposit %s, want 20000000000 1000000000 500000000",
+					child.Value, child.Fee, child.CuratorDeposit)
+			}
+			if child.Status != tt.status || child.Curator != tt.curator || child.Beneficiary != tt.beneficiary ||
+				child.UnlockAt != tt.unlockAt {
+				t.Errorf("got status %s curator %q beneficiary %q unlock at %d, want %s %q %q %d",
+					child.Status, child.Curator, child.Beneficiary, child.UnlockAt,
+					tt.status, tt.curator, tt.beneficiary, tt.unlockAt)
+			}
+		})
+	}
+}
+
+func TestDecodeBountyUnknownStatus(t *testing.T) {
+	raw, _ := hex.DecodeString(bountyBlobs["proposed"])
+	raw[len(raw)-1] = 7
+	if _, err := decodeBounty(raw, 7, 42); err == nil {
+		t.Error("decodeBounty accepted status variant 7")
+	}
+}
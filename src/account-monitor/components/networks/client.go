@@ -0,0 +1,219 @@
+package networks
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gsconfig "github.com/centrifuge/go-substrate-rpc-client/v4/config"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// rpcClient adapts a gethrpc client dialed with custom options to the
+// gsrpc client interface
+type rpcClient struct {
+	*gethrpc.Client
+	url string
+}
+
+func (c *rpcClient) URL() string {
+	return c.url
+}
+
+// headerTransport adds the network's auth headers to every request
+type headerTransport struct {
+	header http.Header
+	base   http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+// newSubstrateAPI connects to the network's endpoint. When auth_header or a
+// TLS CA bundle/pin is set the HTTP rpc_url is used, since the websocket
+// dialer can't carry custom headers or TLS settings; credentials embedded in
+// a websocket URL (user:pass@ or a query string key) work without it. TLS
+// settings on an rpc_url that isn't https:// are an error. Errors never
+// include the raw URL.
+func newSubstrateAPI(network *types.Network) (*gsrpc.SubstrateAPI, error) {
+	header, err := parseAuthHeader(network.AuthHeader.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth_header for %s: %w", network.Name, err)
+	}
+
+	tlsConfig, err := networkTLSConfig(network)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := network.WSURL.String
+	if endpoint == "" || len(header) > 0 || tlsConfig != nil {
+		endpoint = network.RPCURL
+	}
+
+	if len(header) == 0 && tlsConfig == nil {
+		api, err := gsrpc.NewSubstrateAPI(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, redactError(err, endpoint))
+		}
+		return api, nil
+	}
+
+	// A CA bundle or pin on a plain connection would silently trust
+	// whatever answers, so refuse to connect rather than drop the check
+	if tlsConfig != nil && !strings.HasPrefix(endpoint, "https://") {
+		return nil, fmt.Errorf("%s has tls_ca_file or tls_cert_pin set but rpc_url %s is not https://",
+			network.Name, RedactURL(endpoint))
+	}
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		log.Printf("WARNING: %s has an auth_header but rpc_url %s is not HTTP; it is not applied",
+			network.Name, RedactURL(endpoint))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	httpClient := &http.Client{
+		Transport: &headerTransport{header: header, base: transport},
+		Timeout:   gsconfig.Default().DialTimeout,
+	}
+	c, err := gethrpc.DialHTTPWithClient(endpoint, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, redactError(err, endpoint))
+	}
+
+	var cl client.Client = &rpcClient{Client: c, url: endpoint}
+	newRPC, err := rpc.NewRPC(cl)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, redactError(err, endpoint))
+	}
+
+	return &gsrpc.SubstrateAPI{RPC: newRPC, Client: cl}, nil
+}
+
+// networkTLSConfig builds the TLS settings for a network with a CA bundle
+// or certificate pin, or returns nil to use the system roots
+func networkTLSConfig(network *types.Network) (*tls.Config, error) {
+	caFile := strings.TrimSpace(network.TLSCAFile.String)
+	pin := strings.TrimSpace(network.TLSCertPin.String)
+	if caFile == "" && pin == "" {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls_ca_file for %s: %w", network.Name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in tls_ca_file for %s", network.Name)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if pin != "" {
+		want, err := parseCertPin(pin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls_cert_pin for %s: %w", network.Name, err)
+		}
+		// Runs after normal chain verification; any certificate in the
+		// verified chain may match the pin
+		cfg.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, cert := range state.PeerCertificates {
+				sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				if bytes.Equal(sum[:], want) {
+					return nil
+				}
+			}
+			return fmt.Errorf("certificate pin mismatch for %s", network.Name)
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseCertPin accepts a SHA-256 digest of the certificate's public key
+// (SPKI) as "sha256/<base64>" or hex
+func parseCertPin(pin string) ([]byte, error) {
+	var digest []byte
+	var err error
+	if encoded, ok := strings.CutPrefix(pin, "sha256/"); ok {
+		digest, err = base64.StdEncoding.DecodeString(encoded)
+	} else {
+		digest, err = hex.DecodeString(strings.ReplaceAll(pin, ":", ""))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != sha256.Size {
+		return nil, fmt.Errorf("expected a %d byte SHA-256 digest, got %d bytes", sha256.Size, len(digest))
+	}
+	return digest, nil
+}
+
+// parseAuthHeader parses "Name: value" lines; an empty string means no
+// headers
+func parseAuthHeader(raw string) (http.Header, error) {
+	header := make(http.Header)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("expected \"Name: value\"")
+		}
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+// RedactURL strips userinfo, query strings and fragments from an endpoint so
+// it can be logged without leaking credentials
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "<invalid url>"
+	}
+	if u.User != nil {
+		u.User = url.User("***")
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = "***"
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+func redactError(err error, endpoint string) error {
+	msg := err.Error()
+	if !strings.Contains(msg, endpoint) {
+		return err
+	}
+	return fmt.Errorf("%s", strings.ReplaceAll(msg, endpoint, RedactURL(endpoint)))
+}
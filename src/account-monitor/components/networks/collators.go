@@ -0,0 +1,288 @@
+package networks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// CollatorStatus describes a collator's standing in the active set
+type CollatorStatus struct {
+	// Pallet is CollatorSelection or ParachainStaking
+	Pallet       string
+	Selected     bool
+	Invulnerable bool
+	// Bond and MinBond are nil when the pallet doesn't expose them
+	Bond    *big.Int
+	MinBond *big.Int
+	// AuthorshipKnown is set when the pallet records block authorship;
+	// MissedSessions then counts the recent sessions (rounds) in which the
+	// collator authored no block
+	AuthorshipKnown bool
+	MissedSessions  uint32
+}
+
+// accountKey returns the raw account bytes used in storage keys: 20 bytes
+// for EVM addresses, 32 bytes otherwise
+func accountKey(address string) ([]byte, error) {
+	if IsEVMAddress(address) {
+		return hex.DecodeString(address[2:])
+	}
+	accountID, err := decodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return accountID[:], nil
+}
+
+// vecContains reports whether a SCALE Vec of fixed-size account ids
+// contains id
+func vecContains(raw []byte, id []byte) bool {
+	count, n := decodeCompact(raw)
+	if n == 0 {
+		return false
+	}
+	raw = raw[n:]
+	for i := uint64(0); i < count && len(raw) >= len(id); i++ {
+		if bytes.Equal(raw[:len(id)], id) {
+			return true
+		}
+		raw = raw[len(id):]
+	}
+	return false
+}
+
+// GetCollatorStatus reads the collator's selection, bond and authorship from
+// CollatorSelection or ParachainStaking. sessions bounds how many recent
+// rounds are inspected for missed authorship. It returns nil when neither
+// pallet is present.
+func (m *Manager) GetCollatorStatus(networkName, address string, sessions uint32) (*CollatorStatus, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	collatorSelection := m.hasPallet(network.ID, "CollatorSelection")
+	if !collatorSelection && !m.hasPallet(network.ID, "ParachainStaking") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	// read fetches a raw storage value; an item missing from this runtime
+	// version (e.g. Session on a chain without it) reads as absent
+	read := func(pallet, item string, args ...[]byte) ([]byte, bool, error) {
+		storageKey, err := gstypes.CreateStorageKey(meta, pallet, item, args...)
+		if err != nil {
+			return nil, false, nil
+		}
+		var raw gstypes.StorageDataRaw
+		ok, err := getStorage(api, storageKey, &raw, at)
+		return raw, ok, err
+	}
+
+	if collatorSelection {
+		return collatorSelectionStatus(api, meta, key, sessions, read)
+	}
+	return parachainStakingStatus(meta, key, sessions, read)
+}
+
+type storageReader func(pallet, item string, args ...[]byte) ([]byte, bool, error)
+
+// collatorSelectionStatus handles Aura parachains using pallet-collator-
+// selection. The active set is Session.Validators; authorship comes from
+// LastAuthoredBlock measured in KickThreshold blocks, which parachains set
+// to the session period.
+func collatorSelectionStatus(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, key []byte,
+	sessions uint32, read storageReader) (*CollatorStatus, error) {
+
+	status := &CollatorStatus{Pallet: "CollatorSelection"}
+
+	raw, ok, err := read("CollatorSelection", "Invulnerables")
+	if err != nil {
+		return nil, err
+	}
+	status.Invulnerable = ok && vecContains(raw, key)
+
+	// Renamed from Candidates to CandidateList in newer runtimes
+	for _, item := range []string{"CandidateList", "Candidates"} {
+		raw, ok, err := read("CollatorSelection", item)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		var candidates []struct {
+			Who     gstypes.AccountID
+			Deposit gstypes.U128
+		}
+		if err := codec.Decode(raw, &candidates); err != nil {
+			return nil, fmt.Errorf("%w: CollatorSelection.%s: %w", ErrStorageDecode, item, err)
+		}
+		for _, c := range candidates {
+			if bytes.Equal(c.Who[:], key) {
+				status.Bond = c.Deposit.Int
+			}
+		}
+		break
+	}
+
+	if raw, ok, err := read("CollatorSelection", "CandidacyBond"); err != nil {
+		return nil, err
+	} else if ok && status.Bond != nil {
+		var bond gstypes.U128
+		if err := codec.Decode(raw, &bond); err == nil {
+			status.MinBond = bond.Int
+		}
+	}
+
+	raw, ok, err = read("Session", "Validators")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		status.Selected = vecContains(raw, key)
+	} else {
+		status.Selected = status.Invulnerable || status.Bond != nil
+	}
+
+	if !status.Selected || sessions == 0 {
+		return status, nil
+	}
+
+	threshold, err := meta.FindConstantValue("CollatorSelection", "KickThreshold")
+	if err != nil {
+		return status, nil
+	}
+	var period uint32
+	if err := codec.Decode(threshold, &period); err != nil || period == 0 {
+		return status, nil
+	}
+
+	raw, ok, err = read("CollatorSelection", "LastAuthoredBlock", key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(raw) < 4 {
+		return status, nil
+	}
+
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+
+	lastAuthored := binary.LittleEndian.Uint32(raw[:4])
+	status.AuthorshipKnown = true
+	if current := uint32(header.Number); current > lastAuthored {
+		status.MissedSessions = (current - lastAuthored) / period
+	}
+
+	return status, nil
+}
+
+// parachainStakingStatus handles Moonbeam-style parachain staking, where
+// the active set is SelectedCandidates and authorship is recorded as points
+// per round. Points are pruned once a round is paid out, so only the rounds
+// still awaiting payment can be inspected.
+func parachainStakingStatus(meta *gstypes.Metadata, key []byte, sessions uint32,
+	read storageReader) (*CollatorStatus, error) {
+
+	status := &CollatorStatus{Pallet: "ParachainStaking"}
+
+	raw, ok, err := read("ParachainStaking", "SelectedCandidates")
+	if err != nil {
+		return nil, err
+	}
+	status.Selected = ok && vecContains(raw, key)
+
+	// CandidateMetadata starts with the self bond
+	raw, ok, err = read("ParachainStaking", "CandidateInfo", key)
+	if err != nil {
+		return nil, err
+	}
+	if ok && len(raw) >= 16 {
+		var bond gstypes.U128
+		if err := codec.Decode(raw[:16], &bond); err == nil {
+			status.Bond = bond.Int
+		}
+	}
+
+	// Renamed from MinCollatorStk to MinCandidateStk
+	for _, name := range []string{"MinCandidateStk", "MinCollatorStk"} {
+		value, err := meta.FindConstantValue("ParachainStaking", name)
+		if err != nil {
+			continue
+		}
+		var min gstypes.U128
+		if err := codec.Decode(value, &min); err == nil {
+			status.MinBond = min.Int
+		}
+		break
+	}
+
+	if !status.Selected || sessions == 0 {
+		return status, nil
+	}
+
+	// RoundInfo starts with the current round number
+	raw, ok, err = read("ParachainStaking", "Round")
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(raw) < 4 {
+		return status, nil
+	}
+	current := binary.LittleEndian.Uint32(raw[:4])
+	if current == 0 {
+		return status, nil
+	}
+
+	status.AuthorshipKnown = true
+	for round := current - 1; round > 0 && current-round <= sessions; round-- {
+		roundKey := make([]byte, 4)
+		binary.LittleEndian.PutUint32(roundKey, round)
+
+		// Only rounds where the collator was in the active set count
+		if _, staked, err := read("ParachainStaking", "AtStake", roundKey, key); err != nil {
+			return nil, err
+		} else if !staked {
+			break
+		}
+
+		raw, ok, err := read("ParachainStaking", "AwardedPts", roundKey, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok && len(raw) >= 4 && binary.LittleEndian.Uint32(raw[:4]) > 0 {
+			break
+		}
+		status.MissedSessions++
+	}
+
+	return status, nil
+}
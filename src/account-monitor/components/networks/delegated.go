@@ -0,0 +1,52 @@
+package networks
+
+import (
+	"log"
+	"math/big"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// addDelegation reads the account's DelegatedStaking delegation into the
+// balance. The delegated funds stay in the delegator's account on hold, so
+// they count as bonded rather than free. Runtimes that moved nomination
+// pools to delegated staking record every pool member as a delegator of
+// the pool, and that stake is already counted under NominationPools.
+func (m *Manager) addDelegation(network *types.Network, api *gsrpc.SubstrateAPI, meta *gstypes.Metadata,
+	accountID gstypes.AccountID, at *gstypes.Hash, balance *types.Balance) {
+
+	if !m.hasPallet(network.ID, "DelegatedStaking") {
+		return
+	}
+
+	key, err := gstypes.CreateStorageKey(meta, "DelegatedStaking", "Delegators", accountID[:])
+	if err != nil {
+		log.Printf("Failed to read DelegatedStaking delegation on %s: %v", network.Name, err)
+		return
+	}
+	var delegation struct {
+		Agent  gstypes.AccountID
+		Amount gstypes.U128
+	}
+	ok, err := getStorage(api, key, &delegation, at)
+	if err != nil {
+		log.Printf("Failed to read DelegatedStaking delegation on %s: %v", network.Name, err)
+		return
+	}
+	if !ok || delegation.Amount.Sign() == 0 {
+		return
+	}
+
+	balance.DelegatedTo = encodeSS58(delegation.Agent[:], network.SS58Prefix)
+	balance.Delegated = new(big.Int).Set(delegation.Amount.Int)
+	if _, pooled := balance.BondedBySource["NominationPools"]; pooled {
+		return
+	}
+	if balance.BondedBySource == nil {
+		balance.BondedBySource = make(map[string]*big.Int)
+	}
+	balance.BondedBySource["DelegatedStaking"] = balance.Delegated
+	balance.Bonded = new(big.Int).Add(balance.Bonded, balance.Delegated)
+}
@@ -0,0 +1,225 @@
+package networks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	// Asset metadata keys read per state_queryStorageAt call
+	assetMetadataBatchSize = 200
+	// Rows per multi-row network_tokens insert
+	assetInsertBatchSize = 500
+)
+
+// discoveredAsset is one asset's id and metadata ready to store
+type discoveredAsset struct {
+	ID       uint32
+	Metadata AssetMetadata
+}
+
+// fetchAssetMetadata runs fetch over ids in batches on DiscoveryWorkers
+// goroutines and returns the results in id order. It stops early when ctx
+// is canceled.
+func (m *Manager) fetchAssetMetadata(ctx context.Context, ids []uint32,
+	fetch func(batch []uint32) []AssetMetadata) ([]discoveredAsset, error) {
+
+	workers := max(m.config.Load().DiscoveryWorkers, 1)
+
+	assets := make([]discoveredAsset, len(ids))
+	batches := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range batches {
+				end := min(start+assetMetadataBatchSize, len(ids))
+				for j, metadata := range fetch(ids[start:end]) {
+					assets[start+j] = discoveredAsset{ID: ids[start+j], Metadata: metadata}
+				}
+			}
+		}()
+	}
+
+send:
+	for start := 0; start < len(ids); start += assetMetadataBatchSize {
+		select {
+		case <-ctx.Done():
+			break send
+		case batches <- start:
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	return assets, ctx.Err()
+}
+
+// batchAssetMetadata reads the Metadata of a batch of assets in one
+// state_queryStorageAt call, falling back to one read per asset if the node
+// rejects the batch
+func (m *Manager) batchAssetMetadata(api *gsrpc.SubstrateAPI, palletName string, ids []uint32) []AssetMetadata {
+	keys := make([]gstypes.StorageKey, len(ids))
+	for i, id := range ids {
+		keys[i] = assetMetadataKey(palletName, id)
+	}
+
+	results := make([]AssetMetadata, len(ids))
+
+	changeSets, err := api.RPC.State.QueryStorageAtLatest(keys)
+	if err != nil {
+		for i, id := range ids {
+			results[i] = m.getAssetMetadata(api, palletName, id)
+		}
+		return results
+	}
+
+	values := make(map[string][]byte, len(keys))
+	for _, set := range changeSets {
+		for _, change := range set.Changes {
+			if change.HasStorageData {
+				values[change.StorageKey.Hex()] = change.StorageData
+			}
+		}
+	}
+
+	for i, id := range ids {
+		results[i] = decodeAssetMetadata(id, values[keys[i].Hex()])
+	}
+	return results
+}
+
+// storeAssets upserts discovered assets into network_tokens with multi-row
+// inserts inside a single transaction
+func (m *Manager) storeAssets(networkID uint, tokenType, palletName string, assets []discoveredAsset) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for start := 0; start < len(assets); start += assetInsertBatchSize {
+		batch := assets[start:min(start+assetInsertBatchSize, len(assets))]
+
+		args := make([]interface{}, 0, len(batch)*7)
+		for _, asset := range batch {
+			args = append(args, networkID, tokenType, fmt.Sprintf("%d", asset.ID),
+				asset.Metadata.Symbol, asset.Metadata.Name, asset.Metadata.Decimals, palletName)
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO network_tokens
+			(network_id, token_type, token_id, symbol, name, decimals, pallet_name, active)
+			VALUES `+strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, TRUE), ", len(batch)), ", ")+`
+			ON DUPLICATE KEY UPDATE
+			symbol = VALUES(symbol),
+			name = VALUES(name),
+			decimals = VALUES(decimals),
+			active = TRUE
+		`, args...)
+		if err != nil {
+			return fmt.Errorf("failed to insert assets %d-%d: %w", batch[0].ID, batch[len(batch)-1].ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AssetChange is an asset that appeared on or disappeared from a chain
+// between two discoveries
+type AssetChange struct {
+	NetworkID uint
+	TokenType string
+	AssetID   string
+	Symbol    string
+	Appeared  bool
+}
+
+// SetAssetChangeHandler registers fn to receive the assets that appeared or
+// disappeared during each discovery
+func (m *Manager) SetAssetChangeHandler(fn func([]AssetChange)) {
+	m.assetChangeHandler = fn
+}
+
+// activeAssetSymbols returns the stored active assets of one type as
+// token id -> symbol
+func (m *Manager) activeAssetSymbols(networkID uint, tokenType string) (map[string]string, error) {
+	rows, err := m.db.Query(`
+		SELECT token_id, symbol FROM network_tokens
+		WHERE network_id = ? AND token_type = ? AND active = TRUE
+	`, networkID, tokenType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	symbols := make(map[string]string)
+	for rows.Next() {
+		var tokenID, symbol string
+		if err := rows.Scan(&tokenID, &symbol); err != nil {
+			return nil, err
+		}
+		symbols[tokenID] = symbol
+	}
+	return symbols, rows.Err()
+}
+
+// reconcileAssets stores the discovered assets, deactivates stored assets
+// that are no longer on chain and reports both directions to the asset
+// change handler. Appearances are not reported on a network's first
+// discovery, when every asset is new.
+func (m *Manager) reconcileAssets(networkID uint, tokenType, palletName string, assets []discoveredAsset) error {
+	previous, err := m.activeAssetSymbols(networkID, tokenType)
+	if err != nil {
+		return err
+	}
+
+	if err := m.storeAssets(networkID, tokenType, palletName, assets); err != nil {
+		return err
+	}
+
+	var changes []AssetChange
+	present := make(map[string]bool, len(assets))
+	for _, asset := range assets {
+		id := fmt.Sprintf("%d", asset.ID)
+		present[id] = true
+		if _, known := previous[id]; !known && len(previous) > 0 {
+			changes = append(changes, AssetChange{NetworkID: networkID, TokenType: tokenType, AssetID: id,
+				Symbol: asset.Metadata.Symbol, Appeared: true})
+		}
+	}
+
+	var gone []interface{}
+	for id, symbol := range previous {
+		if !present[id] {
+			gone = append(gone, id)
+			changes = append(changes, AssetChange{NetworkID: networkID, TokenType: tokenType, AssetID: id, Symbol: symbol})
+		}
+	}
+
+	if len(gone) > 0 {
+		args := append([]interface{}{networkID, tokenType}, gone...)
+		_, err := m.db.Exec(`
+			UPDATE network_tokens SET active = FALSE
+			WHERE network_id = ? AND token_type = ? AND token_id IN (?`+strings.Repeat(", ?", len(gone)-1)+`)
+		`, args...)
+		if err != nil {
+			return fmt.Errorf("failed to deactivate %d missing assets: %w", len(gone), err)
+		}
+		log.Printf("    Deactivated %d %s no longer on chain", len(gone), palletName)
+	}
+
+	if len(changes) > 0 && m.assetChangeHandler != nil {
+		m.assetChangeHandler(changes)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package networks
+
+import "errors"
+
+// Errors returned by the Manager wrap one of these so callers can tell a
+// misconfiguration from a bad value or an endpoint that is down with
+// errors.Is, e.g. to retry or skip a network only when the RPC failed
+var (
+	// ErrNetworkNotFound is returned for a network name with no database row
+	ErrNetworkNotFound = errors.New("network not found")
+
+	// ErrAddressDecode is returned for an address that isn't valid hex or SS58
+	ErrAddressDecode = errors.New("invalid address")
+
+	// ErrRPCUnavailable is returned when connecting to or querying a
+	// network's endpoint fails
+	ErrRPCUnavailable = errors.New("rpc unavailable")
+
+	// ErrStorageDecode is returned when a storage value doesn't decode into
+	// the type expected for it, usually after a runtime upgrade
+	ErrStorageDecode = errors.New("storage decode failed")
+
+	// ErrGenesisMismatch is returned when a network's endpoint serves a
+	// chain other than the one recorded for it
+	ErrGenesisMismatch = errors.New("genesis hash mismatch")
+
+	// ErrEVMAddress is returned for 20 byte H160 addresses, which have no
+	// Substrate AccountID and must be read through the network's EVM RPC
+	ErrEVMAddress = errors.New("H160 address is not a substrate account")
+)
@@ -0,0 +1,318 @@
+package networks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ChainEvent is one decoded System.Events record. Field values are *big.Int
+// for integers, []byte for byte arrays and sequences (e.g. account ids),
+// string, bool, []any for other sequences, tuples and unnamed composites,
+// map[string]any for named composites and scaleVariant for enums.
+type ChainEvent struct {
+	Pallet string
+	Name   string
+	// Fields in declaration order; Names is empty for runtimes that predate
+	// named event fields
+	Fields []any
+	Names  []string
+}
+
+// Field returns the field called name, falling back to the field at index
+// for runtimes without field names
+func (e ChainEvent) Field(name string, index int) any {
+	for i, n := range e.Names {
+		if n == name {
+			return e.Fields[i]
+		}
+	}
+	if len(e.Names) == 0 && index < len(e.Fields) {
+		return e.Fields[index]
+	}
+	return nil
+}
+
+// scaleVariant is a decoded enum value
+type scaleVariant struct {
+	Name   string
+	Fields []any
+	Names  []string
+}
+
+// scaleReader decodes SCALE values by walking the runtime's type registry,
+// so events of every pallet can be decoded or skipped without static types
+type scaleReader struct {
+	types map[int64]*gstypes.Si1Type
+	raw   []byte
+}
+
+// decodeEvents decodes the raw System.Events value with the types described
+// by meta
+func decodeEvents(meta *gstypes.Metadata, raw []byte) ([]ChainEvent, error) {
+	entry, err := meta.FindStorageEntryMetadata("System", "Events")
+	if err != nil {
+		return nil, err
+	}
+	v14, ok := entry.(gstypes.StorageEntryMetadataV14)
+	if !ok || !v14.Type.IsPlainType {
+		return nil, fmt.Errorf("unsupported System.Events metadata")
+	}
+
+	r := &scaleReader{types: meta.AsMetadataV14.EfficientLookup, raw: raw}
+	value, err := r.decode(v14.Type.AsPlainType.Int64())
+	if err != nil {
+		return nil, err
+	}
+	if len(r.raw) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after events", len(r.raw))
+	}
+
+	records, _ := value.([]any)
+	events := make([]ChainEvent, 0, len(records))
+	for _, record := range records {
+		// EventRecord is { phase, event, topics }; the runtime event is an
+		// enum of pallets wrapping each pallet's event enum
+		fields, _ := record.(map[string]any)
+		pallet, ok := fields["event"].(scaleVariant)
+		if !ok || len(pallet.Fields) != 1 {
+			continue
+		}
+		event, ok := pallet.Fields[0].(scaleVariant)
+		if !ok {
+			continue
+		}
+		events = append(events, ChainEvent{Pallet: pallet.Name, Name: event.Name, Fields: event.Fields, Names: event.Names})
+	}
+	return events, nil
+}
+
+func (r *scaleReader) take(n int) ([]byte, error) {
+	if n < 0 || len(r.raw) < n {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	b := r.raw[:n]
+	r.raw = r.raw[n:]
+	return b, nil
+}
+
+func (r *scaleReader) compact() (*big.Int, error) {
+	value, n := decodeCompactBig(r.raw)
+	if n == 0 {
+		return nil, fmt.Errorf("invalid compact")
+	}
+	r.raw = r.raw[n:]
+	return value, nil
+}
+
+func (r *scaleReader) decode(id int64) (any, error) {
+	t, ok := r.types[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %d", id)
+	}
+	def := t.Def
+
+	switch {
+	case def.IsComposite:
+		return r.fields(def.Composite.Fields)
+
+	case def.IsVariant:
+		index, err := r.take(1)
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range def.Variant.Variants {
+			if byte(variant.Index) != index[0] {
+				continue
+			}
+			v := scaleVariant{Name: string(variant.Name)}
+			for _, f := range variant.Fields {
+				value, err := r.decode(f.Type.Int64())
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", variant.Name, err)
+				}
+				v.Fields = append(v.Fields, value)
+				if f.HasName {
+					v.Names = append(v.Names, string(f.Name))
+				}
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("unknown variant %d of type %d", index[0], id)
+
+	case def.IsSequence:
+		count, err := r.compact()
+		if err != nil {
+			return nil, err
+		}
+		return r.items(def.Sequence.Type.Int64(), int(count.Int64()))
+
+	case def.IsArray:
+		return r.items(def.Array.Type.Int64(), int(def.Array.Len))
+
+	case def.IsTuple:
+		values := make([]any, 0, len(def.Tuple))
+		for _, element := range def.Tuple {
+			value, err := r.decode(element.Int64())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+
+	case def.IsPrimitive:
+		return r.primitive(def.Primitive.Si0TypeDefPrimitive)
+
+	case def.IsCompact:
+		return r.compact()
+
+	case def.IsBitSequence:
+		bits, err := r.compact()
+		if err != nil {
+			return nil, err
+		}
+		store := 1
+		if t, ok := r.types[def.BitSequence.BitStoreType.Int64()]; ok && t.Def.IsPrimitive {
+			store = primitiveSize(t.Def.Primitive.Si0TypeDefPrimitive)
+		}
+		words := (int(bits.Int64()) + 8*store - 1) / (8 * store)
+		_, err = r.take(words * store)
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("unsupported definition of type %d", id)
+}
+
+// fields decodes a composite's fields: a map when they are named, the value
+// itself for a single unnamed field (e.g. AccountId32) and a list otherwise
+func (r *scaleReader) fields(fields []gstypes.Si1Field) (any, error) {
+	values := make([]any, 0, len(fields))
+	for _, f := range fields {
+		value, err := r.decode(f.Type.Int64())
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	if len(fields) > 0 && fields[0].HasName {
+		named := make(map[string]any, len(fields))
+		for i, f := range fields {
+			named[string(f.Name)] = values[i]
+		}
+		return named, nil
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// items decodes count elements, returning bytes for u8 elements
+func (r *scaleReader) items(element int64, count int) (any, error) {
+	if t, ok := r.types[element]; ok && t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == gstypes.IsU8 {
+		return r.take(count)
+	}
+
+	values := make([]any, 0, min(count, len(r.raw)))
+	for i := 0; i < count; i++ {
+		value, err := r.decode(element)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (r *scaleReader) primitive(p gstypes.Si0TypeDefPrimitive) (any, error) {
+	switch p {
+	case gstypes.IsBool:
+		b, err := r.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case gstypes.IsStr:
+		size, err := r.compact()
+		if err != nil {
+			return nil, err
+		}
+		b, err := r.take(int(size.Int64()))
+		return string(b), err
+	}
+
+	size := primitiveSize(p)
+	if size == 0 {
+		return nil, fmt.Errorf("unsupported primitive %d", p)
+	}
+	b, err := r.take(size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Little endian to big endian for big.Int
+	be := make([]byte, size)
+	for i := range b {
+		be[size-1-i] = b[i]
+	}
+	value := new(big.Int).SetBytes(be)
+	if p >= gstypes.IsI8 && size > 0 && b[size-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(8*size)))
+	}
+	return value, nil
+}
+
+// primitiveSize returns the encoded size of a fixed-width primitive
+func primitiveSize(p gstypes.Si0TypeDefPrimitive) int {
+	switch p {
+	case gstypes.IsU8, gstypes.IsI8:
+		return 1
+	case gstypes.IsU16, gstypes.IsI16:
+		return 2
+	case gstypes.IsChar, gstypes.IsU32, gstypes.IsI32:
+		return 4
+	case gstypes.IsU64, gstypes.IsI64:
+		return 8
+	case gstypes.IsU128, gstypes.IsI128:
+		return 16
+	case gstypes.IsU256, gstypes.IsI256:
+		return 32
+	}
+	return 0
+}
+
+// decodeCompactBig decodes a SCALE compact integer of any size, returning
+// the value and the bytes consumed, or zero bytes if raw is malformed
+func decodeCompactBig(raw []byte) (*big.Int, int) {
+	if len(raw) == 0 {
+		return nil, 0
+	}
+	switch raw[0] & 3 {
+	case 0:
+		return big.NewInt(int64(raw[0] >> 2)), 1
+	case 1:
+		if len(raw) < 2 {
+			return nil, 0
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint16(raw) >> 2)), 2
+	case 2:
+		if len(raw) < 4 {
+			return nil, 0
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint32(raw) >> 2)), 4
+	}
+
+	size := int(raw[0]>>2) + 4
+	if len(raw) < 1+size {
+		return nil, 0
+	}
+	be := make([]byte, size)
+	for i := 0; i < size; i++ {
+		be[size-1-i] = raw[1+i]
+	}
+	return new(big.Int).SetBytes(be), 1 + size
+}
@@ -0,0 +1,119 @@
+package networks
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// ERC-20 balanceOf(address) selector
+const erc20BalanceOf = "0x70a08231"
+
+// getEVMClient returns the cached Ethereum JSON-RPC client for a network
+// with an evm_rpc_url, dialing it on first use
+func (m *Manager) getEVMClient(networkName string) (*gethrpc.Client, error) {
+	m.mu.RLock()
+	client, exists := m.evmClients[networkName]
+	m.mu.RUnlock()
+
+	if exists {
+		return client, nil
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := strings.TrimSpace(network.EVMRPCURL.String)
+	if endpoint == "" {
+		return nil, fmt.Errorf("%s has no evm_rpc_url", networkName)
+	}
+
+	client, err = gethrpc.Dial(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, redactError(err, endpoint))
+	}
+
+	m.mu.Lock()
+	m.evmClients[networkName] = client
+	m.mu.Unlock()
+
+	return client, nil
+}
+
+// evmBlockTag is the block EVM reads target, matching the network's read
+// target; a "finalized+N" target reads at the finalized block
+func (m *Manager) evmBlockTag(networkName string) string {
+	if m.networkReadTarget(networkName).best {
+		return "latest"
+	}
+	return "finalized"
+}
+
+// GetEVMBalance reads an H160 account's native balance through the
+// network's Ethereum JSON-RPC (eth_getBalance)
+func (m *Manager) GetEVMBalance(networkName, address string) (types.Balance, error) {
+	if !IsEVMAddress(address) {
+		return types.Balance{}, fmt.Errorf("%w %s: not an H160 address", ErrAddressDecode, address)
+	}
+
+	client, err := m.getEVMClient(networkName)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	var result string
+	if err := client.Call(&result, "eth_getBalance", address, m.evmBlockTag(networkName)); err != nil {
+		return types.Balance{}, fmt.Errorf("%w: eth_getBalance on %s: %w", ErrRPCUnavailable, networkName, err)
+	}
+	return evmBalance(result)
+}
+
+// GetERC20Balance reads an H160 account's balance of an ERC-20 contract
+// through the network's Ethereum JSON-RPC (eth_call of balanceOf)
+func (m *Manager) GetERC20Balance(networkName, address, contract string) (types.Balance, error) {
+	if !IsEVMAddress(address) {
+		return types.Balance{}, fmt.Errorf("%w %s: not an H160 address", ErrAddressDecode, address)
+	}
+	if !IsEVMAddress(contract) {
+		return types.Balance{}, fmt.Errorf("invalid ERC-20 contract address %s", contract)
+	}
+
+	client, err := m.getEVMClient(networkName)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	call := map[string]string{
+		"to":   contract,
+		"data": erc20BalanceOf + strings.Repeat("0", 24) + strings.ToLower(address[2:]),
+	}
+	var result string
+	if err := client.Call(&result, "eth_call", call, m.evmBlockTag(networkName)); err != nil {
+		return types.Balance{}, fmt.Errorf("%w: balanceOf %s on %s: %w", ErrRPCUnavailable, contract, networkName, err)
+	}
+	return evmBalance(result)
+}
+
+// evmBalance converts a hex quantity or uint256 return value to a Balance
+// held entirely as free
+func evmBalance(result string) (types.Balance, error) {
+	digits := strings.TrimPrefix(result, "0x")
+	amount := big.NewInt(0)
+	if digits != "" {
+		if _, ok := amount.SetString(digits, 16); !ok {
+			return types.Balance{}, fmt.Errorf("%w: invalid EVM quantity %q", ErrStorageDecode, result)
+		}
+	}
+
+	return types.Balance{
+		Free:     amount,
+		Reserved: big.NewInt(0),
+		Frozen:   big.NewInt(0),
+		Bonded:   big.NewInt(0),
+		Total:    new(big.Int).Set(amount),
+	}, nil
+}
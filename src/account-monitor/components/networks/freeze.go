@@ -0,0 +1,51 @@
+package networks
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// nativeFreeze reports why native balances on the network can't be moved,
+// or "" when they can. Only SafeMode is checked: while it is entered, calls
+// outside the runtime's whitelist (balance transfers included) are
+// rejected. Chains without the pallet are skipped.
+func (m *Manager) nativeFreeze(network *types.Network, api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, at *gstypes.Hash) string {
+	if !m.hasPallet(network.ID, "SafeMode") {
+		return ""
+	}
+
+	key, err := gstypes.CreateStorageKey(meta, "SafeMode", "EnteredUntil")
+	if err != nil {
+		return ""
+	}
+	var until gstypes.U32
+	ok, err := getStorage(api, key, &until, at)
+	if err != nil {
+		log.Printf("Failed to read SafeMode.EnteredUntil on %s: %v", network.Name, err)
+		return ""
+	}
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("safe mode until block %d", until)
+}
+
+// accountFrozen returns the frozen part of an AccountData's free balance.
+// Current runtimes store {free, reserved, frozen, flags} and mark each
+// account they have migrated with the top bit of flags; older runtimes, and
+// accounts not yet migrated, store {free, reserved, misc_frozen,
+// fee_frozen}, where the larger of the two is what can't be transferred.
+func accountFrozen(third, fourth *big.Int) *big.Int {
+	if fourth.Bit(127) == 1 {
+		return new(big.Int).Set(third)
+	}
+	if third.Cmp(fourth) >= 0 {
+		return new(big.Int).Set(third)
+	}
+	return new(big.Int).Set(fourth)
+}
@@ -0,0 +1,60 @@
+package networks
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// SetGenesisMismatchHandler registers fn to be told when a network's
+// endpoint serves a different chain than the genesis hash recorded for it.
+// It is called once per wrong hash, not on every refused connection.
+func (m *Manager) SetGenesisMismatchHandler(fn func(networkName, expected, actual string)) {
+	m.genesisMismatchHandler = fn
+}
+
+// verifyGenesis checks that a new connection serves the network's chain. The
+// first hash seen is recorded; after that an endpoint with another genesis
+// (a swapped or misconfigured URL) is refused with ErrGenesisMismatch.
+func (m *Manager) verifyGenesis(network *types.Network, api *gsrpc.SubstrateAPI) error {
+	hash, err := api.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get genesis hash of %s: %w", ErrRPCUnavailable, network.Name, err)
+	}
+	actual := hash.Hex()
+
+	expected := network.GenesisHash.String
+	if !network.GenesisHash.Valid || expected == "" {
+		if err := m.db.SetGenesisHash(network.ID, actual); err != nil {
+			log.Printf("Failed to record genesis hash of %s: %v", network.Name, err)
+		} else {
+			log.Printf("Recorded genesis hash of %s: %s", network.Name, actual)
+		}
+		return nil
+	}
+
+	if strings.EqualFold(expected, actual) {
+		m.genesisMu.Lock()
+		delete(m.genesisMismatches, network.Name)
+		m.genesisMu.Unlock()
+		return nil
+	}
+
+	m.genesisMu.Lock()
+	alerted := m.genesisMismatches[network.Name] == actual
+	if !alerted && m.genesisMismatchHandler != nil {
+		m.genesisMismatches[network.Name] = actual
+	}
+	m.genesisMu.Unlock()
+
+	if !alerted {
+		log.Printf("ERROR: %s endpoint serves genesis %s, expected %s; refusing to use it", network.Name, actual, expected)
+		if m.genesisMismatchHandler != nil {
+			m.genesisMismatchHandler(network.Name, expected, actual)
+		}
+	}
+	return fmt.Errorf("%w on %s: expected %s, got %s", ErrGenesisMismatch, network.Name, expected, actual)
+}
@@ -0,0 +1,100 @@
+package networks
+
+import (
+	"fmt"
+	"log"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// SetSudoKeyChangeHandler registers fn to be told when discovery finds a
+// network's Sudo.Key changed from the recorded one. current is empty when
+// the key was removed.
+func (m *Manager) SetSudoKeyChangeHandler(fn func(networkName, previous, current string)) {
+	m.sudoKeyChangeHandler = fn
+}
+
+// reconcileSudoKey reads Sudo.Key on chains with the Sudo pallet and
+// compares it with networks.sudo_key. The first key read is recorded
+// without alerting.
+func (m *Manager) reconcileSudoKey(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, network types.Network) {
+	key, err := gstypes.CreateStorageKey(meta, "Sudo", "Key")
+	if err != nil {
+		// No Sudo pallet
+		return
+	}
+
+	var sudo gstypes.OptionBytes32
+	if _, err := getStorage(api, key, &sudo, nil); err != nil {
+		log.Printf("Failed to read Sudo.Key on %s: %v", network.Name, err)
+		return
+	}
+	current := ""
+	if ok, account := sudo.Unwrap(); ok {
+		current = codec.HexEncodeToString(account[:])
+	}
+
+	previous := network.SudoKey.String
+	if network.SudoKey.Valid && previous == current {
+		return
+	}
+	if err := m.db.SetSudoKey(network.ID, current); err != nil {
+		log.Printf("Failed to record sudo key of %s: %v", network.Name, err)
+		return
+	}
+	if !network.SudoKey.Valid {
+		log.Printf("Recorded sudo key of %s: %s", network.Name, current)
+		return
+	}
+
+	log.Printf("WARNING: sudo key of %s changed from %s to %s", network.Name, previous, current)
+	if m.sudoKeyChangeHandler != nil {
+		m.sudoKeyChangeHandler(network.Name, previous, current)
+	}
+}
+
+// GetCollectiveMembers returns the members of a collective pallet (Council,
+// TechnicalCommittee) as hex account ids. It returns nil when the network
+// doesn't have the pallet.
+func (m *Manager) GetCollectiveMembers(networkName, pallet string) (map[string]bool, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, pallet) {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := gstypes.CreateStorageKey(meta, pallet, "Members")
+	if err != nil {
+		return nil, err
+	}
+	var members []gstypes.AccountID
+	if _, err := getStorage(api, key, &members, at); err != nil {
+		return nil, fmt.Errorf("%s.Members on %s: %w", pallet, networkName, err)
+	}
+
+	set := make(map[string]bool, len(members))
+	for _, member := range members {
+		set[codec.HexEncodeToString(member[:])] = true
+	}
+	return set, nil
+}
@@ -0,0 +1,167 @@
+package networks
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Identity is an account's on-chain identity and its registrar judgements
+type Identity struct {
+	Display string
+	// Judgements maps registrar index to judgement, e.g. KnownGood
+	Judgements map[uint32]string
+}
+
+// Judgement variants in declaration order
+var judgementNames = []string{"Unknown", "FeePaid", "Reasonable", "KnownGood", "OutOfDate", "LowQuality", "Erroneous"}
+
+// GetIdentity reads Identity.IdentityOf for the account. It returns nil when
+// the account has no identity or the network has no Identity pallet.
+func (m *Manager) GetIdentity(networkName, address string) (*Identity, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Identity") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	storageKey, err := gstypes.CreateStorageKey(meta, "Identity", "IdentityOf", key)
+	if err != nil {
+		return nil, err
+	}
+	var raw gstypes.StorageDataRaw
+	ok, err := getStorage(api, storageKey, &raw, at)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	identity, err := decodeRegistration(raw, legacyIdentityInfo(meta))
+	if err != nil {
+		return nil, fmt.Errorf("%w: identity of %s on %s: %w", ErrStorageDecode, address, networkName, err)
+	}
+	return identity, nil
+}
+
+// legacyIdentityInfo reports whether the runtime's IdentityInfo still
+// starts with the free-form additional fields, which runtimes dropped when
+// identity moved to the People chains
+func legacyIdentityInfo(meta *gstypes.Metadata) bool {
+	for _, t := range meta.AsMetadataV14.Lookup.Types {
+		path := t.Type.Path
+		if len(path) == 0 || string(path[len(path)-1]) != "IdentityInfo" || !t.Type.Def.IsComposite {
+			continue
+		}
+		fields := t.Type.Def.Composite.Fields
+		return len(fields) > 0 && string(fields[0].Name) == "additional"
+	}
+	return false
+}
+
+// decodeRegistration decodes a Registration: the judgements, the deposit
+// and the IdentityInfo, of which only the display name is kept. Newer
+// runtimes store (Registration, Option<Username>), which decodes the same
+// since the username trails the registration.
+func decodeRegistration(raw []byte, legacy bool) (*Identity, error) {
+	identity := &Identity{Judgements: make(map[uint32]string)}
+
+	count, n := decodeCompact(raw)
+	if n == 0 {
+		return nil, fmt.Errorf("missing judgements")
+	}
+	raw = raw[n:]
+	for i := uint64(0); i < count; i++ {
+		if len(raw) < 5 {
+			return nil, fmt.Errorf("truncated judgement")
+		}
+		registrar := binary.LittleEndian.Uint32(raw[:4])
+		variant := int(raw[4])
+		raw = raw[5:]
+		if variant >= len(judgementNames) {
+			return nil, fmt.Errorf("unknown judgement variant %d", variant)
+		}
+		// FeePaid carries the fee
+		if judgementNames[variant] == "FeePaid" {
+			if len(raw) < 16 {
+				return nil, fmt.Errorf("truncated judgement fee")
+			}
+			raw = raw[16:]
+		}
+		identity.Judgements[registrar] = judgementNames[variant]
+	}
+
+	if len(raw) < 16 {
+		return nil, fmt.Errorf("missing deposit")
+	}
+	raw = raw[16:]
+
+	if legacy {
+		count, n := decodeCompact(raw)
+		if n == 0 {
+			return nil, fmt.Errorf("missing additional fields")
+		}
+		raw = raw[n:]
+		for i := uint64(0); i < 2*count; i++ {
+			_, size, err := decodeIdentityData(raw)
+			if err != nil {
+				return nil, err
+			}
+			raw = raw[size:]
+		}
+	}
+
+	display, _, err := decodeIdentityData(raw)
+	if err != nil {
+		return nil, err
+	}
+	identity.Display = display
+
+	return identity, nil
+}
+
+// decodeIdentityData decodes a Data field, returning the raw text (empty for
+// None and hashes) and the encoded size
+func decodeIdentityData(raw []byte) (string, int, error) {
+	if len(raw) == 0 {
+		return "", 0, fmt.Errorf("truncated identity data")
+	}
+	switch variant := int(raw[0]); {
+	case variant == 0:
+		return "", 1, nil
+	case variant <= 33:
+		size := variant - 1
+		if len(raw) < 1+size {
+			return "", 0, fmt.Errorf("truncated identity data")
+		}
+		return string(raw[1 : 1+size]), 1 + size, nil
+	case variant <= 37:
+		if len(raw) < 33 {
+			return "", 0, fmt.Errorf("truncated identity data")
+		}
+		return "", 33, nil
+	default:
+		return "", 0, fmt.Errorf("unknown identity data variant %d", variant)
+	}
+}
@@ -0,0 +1,72 @@
+package networks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Keys per state_getKeysPaged request when DiscoveryKeysPageSize is unset
+const defaultKeysPageSize = 1000
+
+// storageKeys lists the storage keys under prefix at the best head. A large
+// registry can exceed the websocket client's fixed read limit (5 MiB) in a
+// single state_getKeys response, which fails the call; the keys are then
+// fetched in pages with state_getKeysPaged instead.
+func (m *Manager) storageKeys(ctx context.Context, api *gsrpc.SubstrateAPI, prefix gstypes.StorageKey) ([]gstypes.StorageKey, error) {
+	keys, err := api.RPC.State.GetKeysLatest(prefix)
+	if err == nil {
+		return keys, nil
+	}
+	if !responseTooLarge(err) {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+
+	pageSize := m.config.Load().DiscoveryKeysPageSize
+	if pageSize <= 0 {
+		pageSize = defaultKeysPageSize
+	}
+	log.Printf("    Key listing too large for one response (%v); fetching pages of %d", err, pageSize)
+	return getKeysPaged(ctx, api, prefix, pageSize)
+}
+
+// getKeysPaged lists the keys under prefix pageSize at a time, each page
+// starting after the last key of the previous one
+func getKeysPaged(ctx context.Context, api *gsrpc.SubstrateAPI, prefix gstypes.StorageKey, pageSize int) ([]gstypes.StorageKey, error) {
+	var keys []gstypes.StorageKey
+	var startKey interface{}
+	for {
+		var page []string
+		if err := api.Client.CallContext(ctx, &page, "state_getKeysPaged", prefix.Hex(), pageSize, startKey); err != nil {
+			if ctx.Err() != nil {
+				return keys, ctx.Err()
+			}
+			return keys, fmt.Errorf("%w: state_getKeysPaged after %d keys: %w", ErrRPCUnavailable, len(keys), err)
+		}
+
+		for _, hexKey := range page {
+			key, err := codec.HexDecodeString(hexKey)
+			if err != nil {
+				return keys, fmt.Errorf("invalid key %q from state_getKeysPaged: %w", hexKey, err)
+			}
+			keys = append(keys, key)
+		}
+		if len(page) < pageSize {
+			return keys, nil
+		}
+		startKey = page[len(page)-1]
+	}
+}
+
+// responseTooLarge reports whether a call failed because the response
+// exceeded the client's message size limit
+func responseTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "read limit") || strings.Contains(msg, "too large") ||
+		strings.Contains(msg, "message too big")
+}
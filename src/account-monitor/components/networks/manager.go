@@ -3,15 +3,19 @@ package networks
 import (
 	"context"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/OneOfOne/xxhash"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
 	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/mr-tron/base58"
@@ -23,19 +27,119 @@ import (
 
 type Manager struct {
 	db      *database.DB
-	config  *config.Config
+	config  *config.Live
 	clients map[string]*gsrpc.SubstrateAPI
 	mu      sync.RWMutex
+
+	// Ethereum JSON-RPC clients for networks with an evm_rpc_url, guarded
+	// by mu
+	evmClients map[string]*gethrpc.Client
+
+	finalizedHeads map[string]finalizedHead
+	headsMu        sync.Mutex
+
+	// Notified of assets appearing or disappearing during discovery
+	assetChangeHandler func([]AssetChange)
+
+	// Notified once per wrong genesis hash seen on a network's endpoint
+	genesisMismatchHandler func(networkName, expected, actual string)
+	genesisMismatches      map[string]string
+	genesisMu              sync.Mutex
+
+	// Notified when discovery finds a network's sudo key changed
+	sudoKeyChangeHandler func(networkName, previous, current string)
 }
 
-func NewManager(db *database.DB, cfg *config.Config) (*Manager, error) {
+// finalizedHead caches the block a network's reads resolved to (the
+// finalized head, or past it by the read_target lag) so a burst of reads
+// doesn't resolve it once per storage query
+type finalizedHead struct {
+	hash      gstypes.Hash
+	fetchedAt time.Time
+}
+
+// Roughly one block; reads within this window share a finalized head
+const finalizedHeadTTL = 6 * time.Second
+
+func NewManager(db *database.DB, cfg *config.Live) (*Manager, error) {
 	return &Manager{
-		db:      db,
-		config:  cfg,
-		clients: make(map[string]*gsrpc.SubstrateAPI),
+		db:                db,
+		config:            cfg,
+		clients:           make(map[string]*gsrpc.SubstrateAPI),
+		evmClients:        make(map[string]*gethrpc.Client),
+		finalizedHeads:    make(map[string]finalizedHead),
+		genesisMismatches: make(map[string]string),
 	}, nil
 }
 
+// readAt resolves the block balance reads should target, per the network's
+// read_target or else UseFinalizedHead. It returns nil to read at the best
+// head, or the finalized head so a reorg can't surface a value from an
+// orphaned block. A "finalized+N" target trades some of that safety for
+// less lag on chains with slow finality.
+func (m *Manager) readAt(networkName string, api *gsrpc.SubstrateAPI) (*gstypes.Hash, error) {
+	target := m.networkReadTarget(networkName)
+	if target.best {
+		return nil, nil
+	}
+
+	m.headsMu.Lock()
+	cached, ok := m.finalizedHeads[networkName]
+	m.headsMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < finalizedHeadTTL {
+		return &cached.hash, nil
+	}
+
+	hash, err := api.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get finalized head for %s: %w", ErrRPCUnavailable, networkName, err)
+	}
+	if target.lag > 0 {
+		hash, err = laggedHead(api, hash, target.lag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to resolve read block for %s: %w", ErrRPCUnavailable, networkName, err)
+		}
+	}
+
+	m.headsMu.Lock()
+	m.finalizedHeads[networkName] = finalizedHead{hash: hash, fetchedAt: time.Now()}
+	m.headsMu.Unlock()
+
+	return &hash, nil
+}
+
+// getStorage reads a storage value at the given block, or the best head if
+// nil. The value is fetched raw and decoded separately so a failed query
+// (ErrRPCUnavailable) can be told apart from a bad value (ErrStorageDecode).
+func getStorage(api *gsrpc.SubstrateAPI, key gstypes.StorageKey, target interface{}, at *gstypes.Hash) (bool, error) {
+	var raw *gstypes.StorageDataRaw
+	var err error
+	if at == nil {
+		raw, err = api.RPC.State.GetStorageRawLatest(key)
+	} else {
+		raw, err = api.RPC.State.GetStorageRaw(key, *at)
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+	if len(*raw) == 0 {
+		return false, nil
+	}
+	if err := codec.Decode(*raw, target); err != nil {
+		return true, fmt.Errorf("%w: %w", ErrStorageDecode, err)
+	}
+	return true, nil
+}
+
+// latestMetadata fetches the runtime metadata at the best head
+func latestMetadata(api *gsrpc.SubstrateAPI) (*gstypes.Metadata, error) {
+	meta, err := api.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get metadata: %w", ErrRPCUnavailable, err)
+	}
+	return meta, nil
+}
+
 func (m *Manager) getClient(networkName string) (*gsrpc.SubstrateAPI, error) {
 	m.mu.RLock()
 	client, exists := m.clients[networkName]
@@ -46,47 +150,131 @@ func (m *Manager) getClient(networkName string) (*gsrpc.SubstrateAPI, error) {
 	}
 
 	// Get network details from database
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := newSubstrateAPI(network)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyGenesis(network, api); err != nil {
+		api.Client.Close()
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.clients[networkName] = api
+	m.mu.Unlock()
+
+	return api, nil
+}
+
+// EvictClient closes and forgets the cached clients of a network, e.g. one
+// that was deactivated. A later read dials again if the network is active.
+func (m *Manager) EvictClient(networkName string) {
+	m.mu.Lock()
+	api, hadClient := m.clients[networkName]
+	delete(m.clients, networkName)
+	evm, hadEVM := m.evmClients[networkName]
+	delete(m.evmClients, networkName)
+	m.mu.Unlock()
+
+	if hadClient {
+		api.Client.Close()
+	}
+	if hadEVM {
+		evm.Close()
+	}
+
+	m.headsMu.Lock()
+	delete(m.finalizedHeads, networkName)
+	m.headsMu.Unlock()
+}
+
+func (m *Manager) getNetwork(networkName string) (*types.Network, error) {
 	networks, err := m.db.GetNetworks()
 	if err != nil {
 		return nil, err
 	}
 
-	var network *types.Network
 	for i := range networks {
 		if networks[i].Name == networkName {
-			network = &networks[i]
-			break
+			return &networks[i], nil
 		}
 	}
 
-	if network == nil {
-		return nil, fmt.Errorf("network not found: %s", networkName)
+	return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, networkName)
+}
+
+// hasPallet reports whether discovery detected the pallet on the network.
+// Pallet-specific reads check this first so that chains without the pallet
+// are skipped quietly instead of failing in CreateStorageKey.
+func (m *Manager) hasPallet(networkID uint, name string) bool {
+	detected, err := m.db.HasPallet(networkID, name)
+	if err != nil {
+		log.Printf("Failed to look up pallet %s on network ID %d: %v", name, networkID, err)
+		return false
 	}
+	return detected
+}
 
-	// Create new client
-	url := network.WSURL.String
-	if url == "" {
-		url = network.RPCURL
+// GetExistentialDeposit reads Balances.ExistentialDeposit from the runtime
+// metadata, falling back to the networks.existential_deposit column for
+// runtimes where the constant can't be read.
+func (m *Manager) GetExistentialDeposit(networkName string) (*big.Int, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
 	}
 
-	api, err := gsrpc.NewSubstrateAPI(url)
+	meta, err := latestMetadata(api)
 	if err != nil {
 		return nil, err
 	}
 
-	m.mu.Lock()
-	m.clients[networkName] = api
-	m.mu.Unlock()
+	raw, err := meta.FindConstantValue("Balances", "ExistentialDeposit")
+	if err == nil {
+		var ed gstypes.U128
+		if err = codec.Decode(raw, &ed); err == nil {
+			return ed.Int, nil
+		}
+	}
 
-	return api, nil
+	network, nErr := m.getNetwork(networkName)
+	if nErr != nil {
+		return nil, nErr
+	}
+
+	if !network.ExistentialDeposit.Valid || network.ExistentialDeposit.String == "" {
+		return nil, fmt.Errorf("existential deposit unavailable for %s: %w", networkName, err)
+	}
+
+	ed, ok := new(big.Int).SetString(strings.TrimSpace(network.ExistentialDeposit.String), 10)
+	if !ok || ed.Sign() < 0 {
+		return nil, fmt.Errorf("invalid existential_deposit %q configured for %s",
+			network.ExistentialDeposit.String, networkName)
+	}
+
+	log.Printf("WARNING: could not read Balances.ExistentialDeposit on %s (%v), using configured fallback %s",
+		networkName, err, ed)
+
+	return ed, nil
 }
 
+// DiscoverNetworks detects the pallets and assets of every active network.
+// Networks that can't be reached are skipped and reported together in the
+// returned error.
 func (m *Manager) DiscoverNetworks(ctx context.Context) error {
 	networks, err := m.db.GetNetworks()
 	if err != nil {
 		return err
 	}
 
+	// Networks that couldn't be reached; the rest are still discovered
+	var failed []error
+
 	for _, network := range networks {
 		select {
 		case <-ctx.Done():
@@ -99,21 +287,28 @@ func (m *Manager) DiscoverNetworks(ctx context.Context) error {
 		api, err := m.getClient(network.Name)
 		if err != nil {
 			log.Printf("Failed to connect to %s: %v", network.Name, err)
+			failed = append(failed, fmt.Errorf("%s: %w", network.Name, err))
 			continue
 		}
 
 		// Get metadata to discover pallets
-		meta, err := api.RPC.State.GetMetadataLatest()
+		meta, err := latestMetadata(api)
 		if err != nil {
 			log.Printf("Failed to get metadata for %s: %v", network.Name, err)
+			failed = append(failed, fmt.Errorf("%s: %w", network.Name, err))
 			continue
 		}
 
+		m.reconcileSS58Prefix(meta, network)
+		m.reconcileTokenProperties(api, network)
+		m.reconcileSudoKey(api, meta, network)
+
 		// Check for specific pallets
 		pallets := []string{
-			"System", "Balances", "Assets", "ForeignAssets",
-			"Bounties", "ChildBounties", "Staking", "ParachainStaking",
-			"CollatorSelection", "Proxy", "Identity",
+			"System", "Balances", "Assets", "ForeignAssets", "PoolAssets",
+			"Bounties", "ChildBounties", "Treasury", "Staking", "NominationPools", "ParachainStaking",
+			"DelegatedStaking", "CollatorSelection", "Proxy", "Identity", "Sudo", "Council", "TechnicalCommittee",
+			"PolkadotXcm", "XcmpQueue", "Scheduler", "Session",
 		}
 
 		for _, palletName := range pallets {
@@ -134,35 +329,116 @@ func (m *Manager) DiscoverNetworks(ctx context.Context) error {
 				}
 			}
 
+			if !hasPallet {
+				// Clear the flag in case a runtime upgrade removed the pallet
+				_, err = m.db.Exec(`
+					UPDATE network_pallets SET detected = FALSE
+					WHERE network_id = ? AND pallet_name = ?
+				`, network.ID, palletName)
+				if err != nil {
+					log.Printf("Failed to store pallet info: %v", err)
+				}
+			}
+
 			if hasPallet {
 				log.Printf("  ✔ Found pallet: %s", palletName)
-				// Special handling for Assets and ForeignAssets pallets
+				// Special handling for the asset pallets
 				switch palletName {
-				case "Assets":
-					m.discoverAssets(api, network.ID, "Assets")
+				case "Assets", "PoolAssets":
+					m.discoverAssets(ctx, api, network.ID, palletName)
 				case "ForeignAssets":
-					m.discoverForeignAssets(api, network.ID)
+					m.discoverForeignAssets(ctx, api, network.ID)
 				}
 			}
 		}
 	}
 
-	return nil
+	return errors.Join(failed...)
 }
 
-// Add helper function
-func Twox128(data []byte) []byte {
-	h := xxhash.NewS64(0)
-	h.Write(data)
-	h2 := xxhash.NewS64(1)
-	h2.Write(data)
+// reconcileSS58Prefix compares networks.ss58_prefix with the runtime's
+// System.SS58Prefix constant, correcting the stored value when enabled
+func (m *Manager) reconcileSS58Prefix(meta *gstypes.Metadata, network types.Network) {
+	raw, err := meta.FindConstantValue("System", "SS58Prefix")
+	if err != nil {
+		log.Printf("Could not read System.SS58Prefix on %s: %v", network.Name, err)
+		return
+	}
 
+	var prefix gstypes.U16
+	if err := codec.Decode(raw, &prefix); err != nil {
+		log.Printf("Failed to decode System.SS58Prefix on %s: %v", network.Name, err)
+		return
+	}
+
+	if uint16(prefix) == network.SS58Prefix {
+		return
+	}
+
+	if !m.config.Load().AutoCorrectSS58Prefix {
+		log.Printf("WARNING: %s ss58_prefix is %d but the chain reports %d", network.Name, network.SS58Prefix, prefix)
+		return
+	}
+
+	_, err = m.db.Exec("UPDATE networks SET ss58_prefix = ? WHERE id = ?", uint16(prefix), network.ID)
+	if err != nil {
+		log.Printf("Failed to correct ss58_prefix for %s: %v", network.Name, err)
+		return
+	}
+	log.Printf("Corrected %s ss58_prefix from %d to %d", network.Name, network.SS58Prefix, prefix)
+}
+
+// Twox128 is two seeded 64-bit xxhash digests (seeds 0 and 1) concatenated
+// little-endian. The one-shot checksums avoid allocating streaming hashers.
+func Twox128(data []byte) []byte {
 	out := make([]byte, 16)
-	binary.LittleEndian.PutUint64(out[0:], h.Sum64())
-	binary.LittleEndian.PutUint64(out[8:], h2.Sum64())
+	binary.LittleEndian.PutUint64(out[0:], xxhash.Checksum64S(data, 0))
+	binary.LittleEndian.PutUint64(out[8:], xxhash.Checksum64S(data, 1))
 	return out
 }
 
+// storagePrefix builds twox128(pallet) ++ twox128(item) with room for extra
+// key bytes so callers can append without reallocating
+func storagePrefix(pallet, item string, extra int) []byte {
+	key := make([]byte, 32, 32+extra)
+	binary.LittleEndian.PutUint64(key[0:], xxhash.Checksum64S([]byte(pallet), 0))
+	binary.LittleEndian.PutUint64(key[8:], xxhash.Checksum64S([]byte(pallet), 1))
+	binary.LittleEndian.PutUint64(key[16:], xxhash.Checksum64S([]byte(item), 0))
+	binary.LittleEndian.PutUint64(key[24:], xxhash.Checksum64S([]byte(item), 1))
+	return key
+}
+
+// encodeSS58 encodes a 32-byte public key as an SS58 address with the given
+// network prefix
+func encodeSS58(pubkey []byte, prefix uint16) string {
+	var data []byte
+	if prefix < 64 {
+		data = []byte{byte(prefix)}
+	} else {
+		// Two byte prefix: 14-bit identifier with the 0b01 marker
+		data = []byte{
+			byte((prefix&0xFC)>>2) | 0x40,
+			byte(prefix>>8) | byte((prefix&0x03)<<6),
+		}
+	}
+	data = append(data, pubkey...)
+
+	checksum := blake2b.Sum512(append([]byte("SS58PRE"), data...))
+	data = append(data, checksum[:2]...)
+
+	return base58.Encode(data)
+}
+
+// PublicKeyHex returns the hex-encoded 32-byte public key for a hex or SS58
+// address, so addresses in different network formats can be compared
+func PublicKeyHex(address string) (string, error) {
+	accountID, err := decodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return codec.HexEncodeToString(accountID[:]), nil
+}
+
 // decodeSS58Address decodes an SS58 address to AccountID
 func decodeSS58Address(address string) (gstypes.AccountID, error) {
 	// Decode base58
@@ -173,27 +449,45 @@ func decodeSS58Address(address string) (gstypes.AccountID, error) {
 
 	// SS58 addresses have the following structure:
 	// [prefix][publicKey][checksum]
-	// For addresses with prefix < 64: 1 byte prefix + 32 bytes pubkey + 2 bytes checksum = 35 bytes
-	// For addresses with prefix >= 64: 2 byte prefix + 32 bytes pubkey + 2 bytes checksum = 36 bytes
-
-	var pubkeyStart int
-	if len(decoded) == 35 {
-		// Single byte prefix
-		pubkeyStart = 1
-	} else if len(decoded) == 36 {
-		// Two byte prefix
-		pubkeyStart = 2
-	} else {
-		return gstypes.AccountID{}, fmt.Errorf("invalid address length: %d", len(decoded))
+	// Prefixes below 64 take one byte, prefixes 64-16383 take two bytes
+	// (first byte 0b01xxxxxx). The public key is 32 bytes, or 33 bytes for a
+	// compressed ecdsa key, followed by a 2 byte checksum.
+	if len(decoded) == 0 {
+		return gstypes.AccountID{}, fmt.Errorf("empty address")
 	}
 
-	// Extract the public key (32 bytes)
-	if len(decoded) < pubkeyStart+32 {
-		return gstypes.AccountID{}, fmt.Errorf("address too short for public key")
+	prefixLen := 1
+	if decoded[0]&0x40 != 0 {
+		prefixLen = 2
+	}
+	if decoded[0]&0x80 != 0 {
+		return gstypes.AccountID{}, fmt.Errorf("invalid SS58 prefix byte 0x%02x", decoded[0])
 	}
 
+	if len(decoded) < prefixLen+2 {
+		return gstypes.AccountID{}, fmt.Errorf("invalid address length: %d", len(decoded))
+	}
+
+	return accountIDFromPublicKey(decoded[prefixLen : len(decoded)-2])
+}
+
+// accountIDFromPublicKey derives the AccountID for a raw public key: 32 byte
+// keys are used as-is and 33 byte compressed ecdsa keys are blake2b-256
+// hashed, as the runtime does
+func accountIDFromPublicKey(pubkey []byte) (gstypes.AccountID, error) {
 	var accountID gstypes.AccountID
-	copy(accountID[:], decoded[pubkeyStart:pubkeyStart+32])
+
+	switch len(pubkey) {
+	case 32:
+		copy(accountID[:], pubkey)
+	case 33:
+		hash := blake2b.Sum256(pubkey)
+		copy(accountID[:], hash[:])
+	case 20:
+		return gstypes.AccountID{}, ErrEVMAddress
+	default:
+		return gstypes.AccountID{}, fmt.Errorf("unsupported public key length %d (expected 32 or 33 bytes)", len(pubkey))
+	}
 
 	return accountID, nil
 }
@@ -204,91 +498,232 @@ func (m *Manager) GetBalance(networkName, addressStr string) (types.Balance, err
 		return types.Balance{}, err
 	}
 
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
 	// Get metadata
-	meta, err := api.RPC.State.GetMetadataLatest()
+	meta, err := latestMetadata(api)
 	if err != nil {
 		return types.Balance{}, err
 	}
 
 	// Handle address conversion
-	var accountID gstypes.AccountID
+	accountID, err := decodeAddress(addressStr)
+	if err != nil {
+		return types.Balance{}, err
+	}
 
-	// Remove whitespace
-	addressStr = strings.TrimSpace(addressStr)
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return types.Balance{}, err
+	}
 
-	// If it starts with 0x, it's already hex
-	if strings.HasPrefix(addressStr, "0x") {
-		err = codec.DecodeFromHex(addressStr, &accountID)
-		if err != nil {
-			return types.Balance{}, fmt.Errorf("failed to decode hex address: %w", err)
-		}
-	} else if len(addressStr) == 64 {
-		// It might be hex without 0x prefix (64 chars = 32 bytes)
-		accountIDPtr, err := gstypes.NewAccountIDFromHexString(addressStr)
-		if err != nil {
-			return types.Balance{}, fmt.Errorf("failed to decode hex string: %w", err)
-		}
-		accountID = *accountIDPtr
-	} else {
-		// Try SS58 decode
-		accountID, err = decodeSS58Address(addressStr)
-		if err != nil {
-			return types.Balance{}, fmt.Errorf("failed to decode SS58 address %s: %w", addressStr, err)
-		}
+	balance, exists, err := readSystemAccount(networkName, api, meta, accountID, at)
+	if err != nil || !exists {
+		return balance, err
 	}
 
-	// Get account info
+	balance.Bonded, balance.BondedBySource = m.getBonded(network, api, meta, accountID, at)
+	m.addDelegation(network, api, meta, accountID, at, &balance)
+	balance.FreezeReason = m.nativeFreeze(network, api, meta, at)
+
+	return balance, nil
+}
+
+// readSystemAccount reads the account's System.Account at the given block.
+// An account that doesn't exist on the network reads as a zero balance with
+// exists false. Bonded is left at zero.
+func readSystemAccount(networkName string, api *gsrpc.SubstrateAPI, meta *gstypes.Metadata,
+	accountID gstypes.AccountID, at *gstypes.Hash) (balance types.Balance, exists bool, err error) {
+
 	key, err := gstypes.CreateStorageKey(meta, "System", "Account", accountID[:])
 	if err != nil {
-		return types.Balance{}, err
+		return types.Balance{}, false, err
 	}
 
-	var accountInfo gstypes.AccountInfo
-	ok, err := api.RPC.State.GetStorageLatest(key, &accountInfo)
+	var raw gstypes.StorageDataRaw
+	ok, err := getStorage(api, key, &raw, at)
 	if err != nil {
-		return types.Balance{}, err
+		return types.Balance{}, false, err
 	}
 
 	if !ok {
 		// Account doesn't exist on this network, return zero balance
 		return types.Balance{
-			Free:       big.NewInt(0),
-			Reserved:   big.NewInt(0),
-			MiscFrozen: big.NewInt(0),
-			FeeFrozen:  big.NewInt(0),
-			Bonded:     big.NewInt(0),
-			Total:      big.NewInt(0),
-		}, nil
+			Free:     big.NewInt(0),
+			Reserved: big.NewInt(0),
+			Frozen:   big.NewInt(0),
+			Bonded:   big.NewInt(0),
+			Total:    big.NewInt(0),
+		}, false, nil
+	}
+
+	accountInfo, err := decodeAccountInfo(meta, raw)
+	if err != nil {
+		return types.Balance{}, false, fmt.Errorf("System.Account on %s: %w", networkName, err)
 	}
 
 	// Convert to our balance type
-	balance := types.Balance{
-		Free:       accountInfo.Data.Free.Int,
-		Reserved:   accountInfo.Data.Reserved.Int,
-		MiscFrozen: accountInfo.Data.MiscFrozen.Int,
-		FeeFrozen:  big.NewInt(0), // FeeFrozen was removed in newer versions
-		Bonded:     big.NewInt(0), // Will be filled from staking pallet
-		Total:      new(big.Int).Add(accountInfo.Data.Free.Int, accountInfo.Data.Reserved.Int),
+	return types.Balance{
+		Free:     accountInfo.free,
+		Reserved: accountInfo.reserved,
+		Frozen:   accountInfo.frozen,
+		Bonded:   big.NewInt(0),
+		Total:    new(big.Int).Add(accountInfo.free, accountInfo.reserved),
+
+		Nonce:       accountInfo.nonce,
+		Consumers:   accountInfo.consumers,
+		Providers:   accountInfo.providers,
+		Sufficients: accountInfo.sufficients,
+	}, true, nil
+}
+
+// decodeAddress converts a hex or SS58 address string to an AccountID
+func decodeAddress(addressStr string) (gstypes.AccountID, error) {
+	// Remove whitespace
+	addressStr = strings.TrimSpace(addressStr)
+
+	hexStr := strings.TrimPrefix(addressStr, "0x")
+	isHex := strings.HasPrefix(addressStr, "0x")
+	if !isHex {
+		// Unprefixed hex is only accepted at public key lengths
+		switch len(hexStr) {
+		case 40, 64, 66:
+			_, err := hex.DecodeString(hexStr)
+			isHex = err == nil
+		}
 	}
 
-	// Check for staking/bonded balance if Staking pallet exists
-	// This would query the Staking pallet for bonded amounts
+	if !isHex {
+		accountID, err := decodeSS58Address(addressStr)
+		if err != nil {
+			return gstypes.AccountID{}, fmt.Errorf("%w %s: %w", ErrAddressDecode, addressStr, err)
+		}
+		return accountID, nil
+	}
 
-	return balance, nil
+	pubkey, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return gstypes.AccountID{}, fmt.Errorf("%w %s: %w", ErrAddressDecode, addressStr, err)
+	}
+
+	accountID, err := accountIDFromPublicKey(pubkey)
+	if err != nil {
+		return gstypes.AccountID{}, fmt.Errorf("%w %s: %w", ErrAddressDecode, addressStr, err)
+	}
+	return accountID, nil
 }
 
-func (m *Manager) discoverAssets(api *gsrpc.SubstrateAPI, networkID uint, palletName string) {
-	log.Printf("    Discovering %s for network ID %d", palletName, networkID)
+// getStakingBonded returns the active bonded amount from Staking.Ledger, or
+// nil if the account isn't bonded. The caller checks for the Staking pallet.
+func (m *Manager) getStakingBonded(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, stash gstypes.AccountID, at *gstypes.Hash) (*big.Int, error) {
+	// Ledger is keyed by controller; fall back to the stash when no
+	// separate controller is set
+	controller := stash
+	key, err := gstypes.CreateStorageKey(meta, "Staking", "Bonded", stash[:])
+	if err != nil {
+		return nil, err
+	}
+	var bondedController gstypes.AccountID
+	if ok, err := getStorage(api, key, &bondedController, at); err != nil {
+		return nil, err
+	} else if ok {
+		controller = bondedController
+	}
 
-	_, err := api.RPC.State.GetMetadataLatest()
+	key, err = gstypes.CreateStorageKey(meta, "Staking", "Ledger", controller[:])
 	if err != nil {
-		log.Printf("Failed to get metadata: %v", err)
-		return
+		return nil, err
+	}
+
+	// Only the leading fields of StakingLedger are needed
+	var ledger struct {
+		Stash  gstypes.AccountID
+		Total  gstypes.UCompact
+		Active gstypes.UCompact
+	}
+	ok, err := getStorage(api, key, &ledger, at)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	active := big.Int(ledger.Active)
+	return &active, nil
+}
+
+// GetRewardDestination reads Staking.Payee for the stash and returns the
+// reward destination (Staked, Stash, Controller, Account(...) or None).
+// An empty string means the chain has no Staking pallet or no payee is set.
+func (m *Manager) GetRewardDestination(networkName, address string) (string, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return "", err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return "", err
+	}
+	if !m.hasPallet(network.ID, "Staking") {
+		return "", nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return "", err
+	}
+
+	accountID, err := decodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := gstypes.CreateStorageKey(meta, "Staking", "Payee", accountID[:])
+	if err != nil {
+		return "", err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return "", err
 	}
 
+	var raw gstypes.StorageDataRaw
+	ok, err := getStorage(api, key, &raw, at)
+	if err != nil {
+		return "", err
+	}
+	if !ok || len(raw) == 0 {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case 0:
+		return "Staked", nil
+	case 1:
+		return "Stash", nil
+	case 2:
+		return "Controller", nil
+	case 3:
+		if len(raw) < 33 {
+			return "", fmt.Errorf("payee account truncated: %d bytes", len(raw))
+		}
+		return fmt.Sprintf("Account(%s)", codec.HexEncodeToString(raw[1:33])), nil
+	case 4:
+		return "None", nil
+	}
+
+	return "", fmt.Errorf("unknown reward destination variant %d", raw[0])
+}
+
+func (m *Manager) discoverAssets(ctx context.Context, api *gsrpc.SubstrateAPI, networkID uint, palletName string) {
+	log.Printf("    Discovering %s for network ID %d", palletName, networkID)
+
 	// Get all storage keys for assets
-	prefix := append(Twox128([]byte(palletName)), Twox128([]byte("Asset"))...)
-	keys, err := api.RPC.State.GetKeysLatest(prefix)
+	prefix := storagePrefix(palletName, "Asset", 0)
+	keys, err := m.storageKeys(ctx, api, prefix)
 	if err != nil {
 		log.Printf("Failed to get asset keys: %v", err)
 		return
@@ -297,11 +732,14 @@ func (m *Manager) discoverAssets(api *gsrpc.SubstrateAPI, networkID uint, pallet
 	log.Printf("    Found %d assets in %s", len(keys), palletName)
 
 	tokenType := "asset"
-	if palletName == "ForeignAssets" {
+	switch palletName {
+	case "ForeignAssets":
 		tokenType = "foreign_asset"
+	case "PoolAssets":
+		tokenType = "pool_asset"
 	}
 
-	// Process each asset
+	ids := make([]uint32, 0, len(keys))
 	for _, key := range keys {
 		// Extract asset ID from the key
 		assetID, err := extractAssetIDFromKey(key[:])
@@ -309,44 +747,47 @@ func (m *Manager) discoverAssets(api *gsrpc.SubstrateAPI, networkID uint, pallet
 			log.Printf("Failed to extract asset ID: %v", err)
 			continue
 		}
+		ids = append(ids, assetID)
+	}
 
-		// Fetch metadata for this asset
-		metadata := m.getAssetMetadata(api, palletName, assetID)
-
-		// Store the asset with proper metadata
-		_, err = m.db.Exec(`
-			INSERT INTO network_tokens 
-			(network_id, token_type, token_id, symbol, name, decimals, pallet_name, active)
-			VALUES (?, ?, ?, ?, ?, ?, ?, TRUE)
-			ON DUPLICATE KEY UPDATE 
-			symbol = VALUES(symbol),
-			name = VALUES(name),
-			decimals = VALUES(decimals),
-			active = TRUE
-		`, networkID, tokenType, fmt.Sprintf("%d", assetID),
-			metadata.Symbol, metadata.Name, metadata.Decimals, palletName)
+	assets, err := m.fetchAssetMetadata(ctx, ids, func(batch []uint32) []AssetMetadata {
+		return m.batchAssetMetadata(api, palletName, batch)
+	})
+	if err != nil {
+		log.Printf("Asset discovery on network ID %d canceled: %v", networkID, err)
+		return
+	}
 
-		if err != nil {
-			log.Printf("Failed to insert asset %d: %v", assetID, err)
-		} else {
-			log.Printf("      Asset %d: %s (%s) - %d decimals",
-				assetID, metadata.Name, metadata.Symbol, metadata.Decimals)
+	// Pool (LP) tokens are created by the asset conversion pallet without
+	// metadata; name them as LP positions rather than generic assets
+	if tokenType == "pool_asset" {
+		for i, asset := range assets {
+			if asset.Metadata.Symbol == fmt.Sprintf("ASSET%d", asset.ID) {
+				assets[i].Metadata.Symbol = fmt.Sprintf("LP%d", asset.ID)
+				assets[i].Metadata.Name = fmt.Sprintf("LP token #%d", asset.ID)
+			}
 		}
 	}
+
+	if err := m.reconcileAssets(networkID, tokenType, palletName, assets); err != nil {
+		log.Printf("Failed to store %s: %v", palletName, err)
+		return
+	}
+	log.Printf("    Stored %d %s", len(assets), palletName)
 }
 
-func (m *Manager) discoverForeignAssets(api *gsrpc.SubstrateAPI, networkID uint) {
+func (m *Manager) discoverForeignAssets(ctx context.Context, api *gsrpc.SubstrateAPI, networkID uint) {
 	log.Printf("    Discovering ForeignAssets for network ID %d", networkID)
 
-	meta, err := api.RPC.State.GetMetadataLatest()
+	meta, err := latestMetadata(api)
 	if err != nil {
 		log.Printf("Failed to get metadata: %v", err)
 		return
 	}
 
 	// Get all storage keys for foreign assets
-	prefix := append(Twox128([]byte("ForeignAssets")), Twox128([]byte("Asset"))...)
-	keys, err := api.RPC.State.GetKeysLatest(prefix)
+	prefix := storagePrefix("ForeignAssets", "Asset", 0)
+	keys, err := m.storageKeys(ctx, api, prefix)
 	if err != nil {
 		log.Printf("Failed to get foreign asset keys: %v", err)
 		return
@@ -355,60 +796,42 @@ func (m *Manager) discoverForeignAssets(api *gsrpc.SubstrateAPI, networkID uint)
 	log.Printf("    Found %d assets in ForeignAssets", len(keys))
 
 	// Map of known foreign assets on Polkadot Asset Hub
-	knownForeignAssets := map[uint32]struct {
-		Symbol   string
-		Name     string
-		Decimals uint8
-	}{
-		50921730: {"KSM", "Kusama", 12},
+	knownForeignAssets := map[uint32]AssetMetadata{
+		50921730: {Symbol: "KSM", Name: "Kusama", Decimals: 12},
 		// Add more known foreign assets here as needed
 	}
 
-	// Process each foreign asset
+	ids := make([]uint32, 0, len(keys))
 	for _, key := range keys {
 		// For ForeignAssets, the key contains a MultiLocation encoded as a u32
 		if len(key[:]) < 52 {
 			continue
 		}
+		ids = append(ids, binary.LittleEndian.Uint32(key[48:52]))
+	}
 
-		// Extract the MultiLocation ID (stored as u32)
-		assetIDBytes := key[48:52]
-		assetID := binary.LittleEndian.Uint32(assetIDBytes)
-
-		var metadata AssetMetadata
-
-		// Check if this is a known foreign asset
-		if known, ok := knownForeignAssets[assetID]; ok {
-			metadata = AssetMetadata{
-				Name:     known.Name,
-				Symbol:   known.Symbol,
-				Decimals: known.Decimals,
+	assets, err := m.fetchAssetMetadata(ctx, ids, func(batch []uint32) []AssetMetadata {
+		results := make([]AssetMetadata, len(batch))
+		for i, assetID := range batch {
+			// Check if this is a known foreign asset, otherwise ask the chain
+			if known, ok := knownForeignAssets[assetID]; ok {
+				results[i] = known
+			} else {
+				results[i] = m.getForeignAssetMetadata(api, assetID, meta)
 			}
-		} else {
-			// Try to get metadata from chain
-			metadata = m.getForeignAssetMetadata(api, assetID, meta)
 		}
+		return results
+	})
+	if err != nil {
+		log.Printf("Foreign asset discovery on network ID %d canceled: %v", networkID, err)
+		return
+	}
 
-		// Store the foreign asset
-		_, err = m.db.Exec(`
-			INSERT INTO network_tokens 
-			(network_id, token_type, token_id, symbol, name, decimals, pallet_name, active)
-			VALUES (?, ?, ?, ?, ?, ?, ?, TRUE)
-			ON DUPLICATE KEY UPDATE 
-			symbol = VALUES(symbol),
-			name = VALUES(name),
-			decimals = VALUES(decimals),
-			active = TRUE
-		`, networkID, "foreign_asset", fmt.Sprintf("%d", assetID),
-			metadata.Symbol, metadata.Name, metadata.Decimals, "ForeignAssets")
-
-		if err != nil {
-			log.Printf("Failed to insert foreign asset %d: %v", assetID, err)
-		} else {
-			log.Printf("      Asset %d: %s (%s) - %d decimals",
-				assetID, metadata.Name, metadata.Symbol, metadata.Decimals)
-		}
+	if err := m.reconcileAssets(networkID, "foreign_asset", "ForeignAssets", assets); err != nil {
+		log.Printf("Failed to store ForeignAssets: %v", err)
+		return
 	}
+	log.Printf("    Stored %d ForeignAssets", len(assets))
 }
 
 func (m *Manager) getForeignAssetMetadata(api *gsrpc.SubstrateAPI, assetID uint32, meta *gstypes.Metadata) AssetMetadata {
@@ -492,60 +915,54 @@ type AssetMetadata struct {
 }
 
 func (m *Manager) getAssetMetadata(api *gsrpc.SubstrateAPI, palletName string, assetID uint32) AssetMetadata {
-	// Create storage key for Metadata
+	var rawData gstypes.StorageDataRaw
+	ok, err := api.RPC.State.GetStorageLatest(assetMetadataKey(palletName, assetID), &rawData)
+	if err != nil || !ok {
+		return decodeAssetMetadata(assetID, nil)
+	}
+	return decodeAssetMetadata(assetID, rawData)
+}
+
+// assetMetadataKey builds the Metadata storage key: prefix +
+// blake2_128(asset_id) + asset_id
+func assetMetadataKey(palletName string, assetID uint32) gstypes.StorageKey {
 	assetIDBytes := make([]byte, 4)
 	binary.LittleEndian.PutUint32(assetIDBytes, assetID)
 
-	// Build storage key manually
-	palletHash := Twox128([]byte(palletName))
-	storageHash := Twox128([]byte("Metadata"))
+	key := storagePrefix(palletName, "Metadata", 16+len(assetIDBytes))
 
 	// Blake2_128_Concat hasher
 	h, _ := blake2b.New(16, nil)
 	h.Write(assetIDBytes)
-	hasher := h.Sum(nil)
-
-	key := append(palletHash, storageHash...)
-	key = append(key, hasher...)
+	key = h.Sum(key)
 	key = append(key, assetIDBytes...)
 
-	// Query the storage
-	var rawData gstypes.StorageDataRaw
-	ok, err := api.RPC.State.GetStorageLatest(gstypes.NewStorageKey(key), &rawData)
-	if err != nil || !ok || len(rawData) == 0 {
-		// Return defaults if no metadata
-		return AssetMetadata{
-			Name:     fmt.Sprintf("Asset #%d", assetID),
-			Symbol:   fmt.Sprintf("ASSET%d", assetID),
-			Decimals: 10,
-		}
+	return gstypes.NewStorageKey(key)
+}
+
+// decodeAssetMetadata decodes an AssetMetadata storage value, falling back
+// to placeholder names when it is missing or truncated
+func decodeAssetMetadata(assetID uint32, data []byte) AssetMetadata {
+	fallback := AssetMetadata{
+		Name:     fmt.Sprintf("Asset #%d", assetID),
+		Symbol:   fmt.Sprintf("ASSET%d", assetID),
+		Decimals: 10,
 	}
 
 	// Manual SCALE decoding
-	data := []byte(rawData)
 	if len(data) < 16 {
-		return AssetMetadata{
-			Name:     fmt.Sprintf("Asset #%d", assetID),
-			Symbol:   fmt.Sprintf("ASSET%d", assetID),
-			Decimals: 10,
-		}
+		return fallback
 	}
 
-	offset := 0
-
 	// Skip deposit (u128 - 16 bytes)
-	offset += 16
+	offset := 16
 
 	// Decode name (Compact<u32> length + bytes)
 	nameLen, bytesRead := decodeCompact(data[offset:])
 	offset += bytesRead
 
 	if offset+int(nameLen) > len(data) {
-		return AssetMetadata{
-			Name:     fmt.Sprintf("Asset #%d", assetID),
-			Symbol:   fmt.Sprintf("ASSET%d", assetID),
-			Decimals: 10,
-		}
+		return fallback
 	}
 
 	name := string(data[offset : offset+int(nameLen)])
@@ -556,11 +973,8 @@ func (m *Manager) getAssetMetadata(api *gsrpc.SubstrateAPI, palletName string, a
 	offset += bytesRead
 
 	if offset+int(symbolLen) > len(data) {
-		return AssetMetadata{
-			Name:     name,
-			Symbol:   fmt.Sprintf("ASSET%d", assetID),
-			Decimals: 10,
-		}
+		fallback.Name = name
+		return fallback
 	}
 
 	symbol := string(data[offset : offset+int(symbolLen)])
@@ -615,24 +1029,24 @@ func decodeCompact(data []byte) (uint64, int) {
 	return 0, 0
 }
 
-func (m *Manager) GetAssetBalance(networkName, address, assetID string) (types.Balance, error) {
+func (m *Manager) GetAssetBalance(networkName, address, tokenType, assetID string) (types.Balance, error) {
 	api, err := m.getClient(networkName)
 	if err != nil {
 		return types.Balance{}, err
 	}
 
-	meta, err := api.RPC.State.GetMetadataLatest()
+	network, err := m.getNetwork(networkName)
 	if err != nil {
 		return types.Balance{}, err
 	}
 
-	// Decode address to AccountID
-	var accountID gstypes.AccountID
-	if strings.HasPrefix(address, "0x") {
-		err = codec.DecodeFromHex(address, &accountID)
-	} else {
-		accountID, err = decodeSS58Address(address)
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return types.Balance{}, err
 	}
+
+	// Decode address to AccountID
+	accountID, err := decodeAddress(address)
 	if err != nil {
 		return types.Balance{}, err
 	}
@@ -646,57 +1060,56 @@ func (m *Manager) GetAssetBalance(networkName, address, assetID string) (types.B
 	assetIDBytes := make([]byte, 4)
 	binary.LittleEndian.PutUint32(assetIDBytes, uint32(assetIDNum))
 
-	// Try Assets pallet
-	key, err := gstypes.CreateStorageKey(meta, "Assets", "Account", assetIDBytes, accountID[:])
-	if err == nil {
-		var assetAccount struct {
-			Balance gstypes.U128
-			Status  uint8
-			Reason  interface{}
-			Extra   interface{}
-		}
-		ok, err := api.RPC.State.GetStorageLatest(key, &assetAccount)
-		if err == nil && ok {
-			return types.Balance{
-				Free:       assetAccount.Balance.Int,
-				Reserved:   big.NewInt(0),
-				MiscFrozen: big.NewInt(0),
-				FeeFrozen:  big.NewInt(0),
-				Bonded:     big.NewInt(0),
-				Total:      assetAccount.Balance.Int,
-			}, nil
-		}
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return types.Balance{}, err
 	}
 
-	// Try ForeignAssets pallet
-	key, err = gstypes.CreateStorageKey(meta, "ForeignAssets", "Account", assetIDBytes, accountID[:])
-	if err == nil {
-		var assetAccount struct {
-			Balance gstypes.U128
-			Status  uint8
-			Reason  interface{}
-			Extra   interface{}
+	// Pool assets share ids with Assets, so they are only read from
+	// PoolAssets; other assets try the Assets pallet, then ForeignAssets.
+	// The balance is a fixed u128 on most runtimes but some declare it
+	// Compact, so the encoding is taken from the metadata.
+	pallets := []string{"Assets", "ForeignAssets"}
+	if tokenType == "pool_asset" {
+		pallets = []string{"PoolAssets"}
+	}
+	for _, pallet := range pallets {
+		if !m.hasPallet(network.ID, pallet) {
+			continue
 		}
-		ok, err := api.RPC.State.GetStorageLatest(key, &assetAccount)
-		if err == nil && ok {
-			return types.Balance{
-				Free:       assetAccount.Balance.Int,
-				Reserved:   big.NewInt(0),
-				MiscFrozen: big.NewInt(0),
-				FeeFrozen:  big.NewInt(0),
-				Bonded:     big.NewInt(0),
-				Total:      assetAccount.Balance.Int,
-			}, nil
+		key, err := gstypes.CreateStorageKey(meta, pallet, "Account", assetIDBytes, accountID[:])
+		if err != nil {
+			return types.Balance{}, err
 		}
+		// A failed read is returned rather than skipped, so it isn't taken
+		// for the asset having no balance
+		var raw gstypes.StorageDataRaw
+		ok, err := getStorage(api, key, &raw, at)
+		if err != nil {
+			return types.Balance{}, fmt.Errorf("%s.Account of asset %s: %w", pallet, assetID, err)
+		}
+		if !ok {
+			continue
+		}
+		free, err := decodeAssetBalance(raw, assetBalanceCompact(meta, pallet))
+		if err != nil {
+			return types.Balance{}, fmt.Errorf("%w: %s.Account of asset %s: %w", ErrStorageDecode, pallet, assetID, err)
+		}
+		return types.Balance{
+			Free:     free,
+			Reserved: big.NewInt(0),
+			Frozen:   big.NewInt(0),
+			Bonded:   big.NewInt(0),
+			Total:    new(big.Int).Set(free),
+		}, nil
 	}
 
 	// Return zero balance if not found
 	return types.Balance{
-		Free:       big.NewInt(0),
-		Reserved:   big.NewInt(0),
-		MiscFrozen: big.NewInt(0),
-		FeeFrozen:  big.NewInt(0),
-		Bonded:     big.NewInt(0),
-		Total:      big.NewInt(0),
+		Free:     big.NewInt(0),
+		Reserved: big.NewInt(0),
+		Frozen:   big.NewInt(0),
+		Bonded:   big.NewInt(0),
+		Total:    big.NewInt(0),
 	}, nil
 }
@@ -0,0 +1,105 @@
+package networks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/OneOfOne/xxhash"
+	"golang.org/x/crypto/blake2b"
+)
+
+// twox128Streaming is the former Twox128, two streaming hashers per call,
+// kept as the reference the one-shot version must match
+func twox128Streaming(data []byte) []byte {
+	h := xxhash.NewS64(0)
+	h.Write(data)
+	h2 := xxhash.NewS64(1)
+	h2.Write(data)
+
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint64(out[0:], h.Sum64())
+	binary.LittleEndian.PutUint64(out[8:], h2.Sum64())
+	return out
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestTwox128Vectors(t *testing.T) {
+	// Storage prefixes as they appear in any Substrate chain's keys
+	vectors := map[string]string{
+		"System":        "26aa394eea5630e07c48ae0c9558cef7",
+		"Account":       "b99d880ec681799c0cf30e8886371da9",
+		"Balances":      "c2261276cc9d1f8598ea4b6a74b15c2f",
+		"TotalIssuance": "57c875e4cff74148e4628f264b974c80",
+		"Timestamp":     "f0c365c3cf59d671eb72da0e7a4113c4",
+		"Now":           "9f1f0515f462cdcf84e0f1d6045dfcbb",
+	}
+	for input, want := range vectors {
+		if got := hex.EncodeToString(Twox128([]byte(input))); got != want {
+			t.Errorf("Twox128(%q) = %s, want %s", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "Assets", "Metadata", "ForeignAssets", "a longer input spanning more than thirty-two bytes"} {
+		if got, want := Twox128([]byte(input)), twox128Streaming([]byte(input)); !bytes.Equal(got, want) {
+			t.Errorf("Twox128(%q) = %x, streaming hashers give %x", input, got, want)
+		}
+	}
+}
+
+func TestStoragePrefix(t *testing.T) {
+	want := mustHex(t, "26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9")
+	key := storagePrefix("System", "Account", 48)
+	if !bytes.Equal(key, want) {
+		t.Errorf("storagePrefix(System, Account) = %x, want %x", key, want)
+	}
+	if cap(key) != 32+48 {
+		t.Errorf("storagePrefix capacity = %d, want %d", cap(key), 32+48)
+	}
+}
+
+func TestAssetMetadataKey(t *testing.T) {
+	// The key as built before pre-sizing: each part appended in turn
+	id := []byte{0xc0, 0x07, 0, 0} // 1984
+	h, _ := blake2b.New(16, nil)
+	h.Write(id)
+	want := append(twox128Streaming([]byte("Assets")), twox128Streaming([]byte("Metadata"))...)
+	want = append(want, h.Sum(nil)...)
+	want = append(want, id...)
+
+	if got := assetMetadataKey("Assets", 1984); !bytes.Equal(got, want) {
+		t.Errorf("assetMetadataKey(Assets, 1984) = %x, want %x", []byte(got), want)
+	}
+}
+
+func BenchmarkTwox128(b *testing.B) {
+	data := []byte("Balances")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Twox128(data)
+	}
+}
+
+func BenchmarkTwox128Streaming(b *testing.B) {
+	data := []byte("Balances")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		twox128Streaming(data)
+	}
+}
+
+func BenchmarkAssetMetadataKey(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		assetMetadataKey("Assets", uint32(i))
+	}
+}
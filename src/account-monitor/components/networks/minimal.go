@@ -0,0 +1,61 @@
+package networks
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+const (
+	// Attempts a minimal profile read makes before giving up
+	minimalReadAttempts = 3
+	// Wait before the first retry, doubled for each one after
+	minimalReadRetry = 2 * time.Second
+)
+
+// GetNativeBalance is the read path of minimal profile accounts: only
+// System.Account, always at the finalized head whatever the network's
+// read_target, retried when the endpoint fails. Staking, delegation and
+// freeze lookups are skipped, so Bonded is zero.
+func (m *Manager) GetNativeBalance(networkName, addressStr string) (types.Balance, error) {
+	accountID, err := decodeAddress(addressStr)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	delay := minimalReadRetry
+	for attempt := 1; ; attempt++ {
+		balance, err := m.readNativeBalance(networkName, accountID)
+		if err == nil || !errors.Is(err, ErrRPCUnavailable) || attempt == minimalReadAttempts {
+			return balance, err
+		}
+		log.Printf("Native balance read on %s failed (attempt %d of %d), retrying in %v: %v",
+			networkName, attempt, minimalReadAttempts, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (m *Manager) readNativeBalance(networkName string, accountID gstypes.AccountID) (types.Balance, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	at, err := api.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return types.Balance{}, fmt.Errorf("%w: failed to get finalized head for %s: %w", ErrRPCUnavailable, networkName, err)
+	}
+
+	balance, _, err := readSystemAccount(networkName, api, meta, accountID, &at)
+	return balance, err
+}
@@ -0,0 +1,97 @@
+package networks
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// chainTokenProperties reads the native token's decimals and symbol from
+// system_properties. Multi-token chains report lists; the first entry is the
+// native token.
+func chainTokenProperties(api *gsrpc.SubstrateAPI) (uint8, string, error) {
+	var props struct {
+		TokenDecimals json.RawMessage `json:"tokenDecimals"`
+		TokenSymbol   json.RawMessage `json:"tokenSymbol"`
+	}
+	if err := api.Client.Call(&props, "system_properties"); err != nil {
+		return 0, "", fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+
+	var decimals []uint8
+	if err := unmarshalOneOrMany(props.TokenDecimals, &decimals); err != nil || len(decimals) == 0 {
+		return 0, "", fmt.Errorf("no tokenDecimals in system_properties")
+	}
+	var symbols []string
+	if err := unmarshalOneOrMany(props.TokenSymbol, &symbols); err != nil || len(symbols) == 0 {
+		return 0, "", fmt.Errorf("no tokenSymbol in system_properties")
+	}
+	return decimals[0], symbols[0], nil
+}
+
+// unmarshalOneOrMany decodes a JSON value or list of values into a slice
+func unmarshalOneOrMany[T any](raw json.RawMessage, out *[]T) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if raw[0] == '[' {
+		return json.Unmarshal(raw, out)
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	*out = []T{v}
+	return nil
+}
+
+// reconcileTokenProperties compares the decimals and symbol stored for the
+// network and its native token with the chain's system_properties,
+// correcting them when enabled. A wrong decimals value misformats every
+// amount, so it is caught here rather than in the summaries.
+func (m *Manager) reconcileTokenProperties(api *gsrpc.SubstrateAPI, network types.Network) {
+	decimals, symbol, err := chainTokenProperties(api)
+	if err != nil {
+		log.Printf("Could not read token properties on %s: %v", network.Name, err)
+		return
+	}
+
+	var tokenDecimals uint8
+	var tokenSymbol string
+	err = m.db.QueryRow(`
+		SELECT decimals, symbol FROM network_tokens
+		WHERE network_id = ? AND token_type = 'native'
+	`, network.ID).Scan(&tokenDecimals, &tokenSymbol)
+	if err != nil {
+		// No native token row yet; only the network columns are compared
+		tokenDecimals, tokenSymbol = decimals, symbol
+	}
+
+	if network.Decimals == decimals && network.Symbol.String == symbol &&
+		tokenDecimals == decimals && tokenSymbol == symbol {
+		return
+	}
+
+	if !m.config.Load().AutoCorrectTokenProperties {
+		log.Printf("WARNING: %s has decimals %d and symbol %q (native token: %d, %q) but the chain reports %d and %q",
+			network.Name, network.Decimals, network.Symbol.String, tokenDecimals, tokenSymbol, decimals, symbol)
+		return
+	}
+
+	if _, err := m.db.Exec("UPDATE networks SET decimals = ?, symbol = ? WHERE id = ?", decimals, symbol, network.ID); err != nil {
+		log.Printf("Failed to correct token properties for %s: %v", network.Name, err)
+		return
+	}
+	_, err = m.db.Exec(`
+		UPDATE network_tokens SET decimals = ?, symbol = ?
+		WHERE network_id = ? AND token_type = 'native'
+	`, decimals, symbol, network.ID)
+	if err != nil {
+		log.Printf("Failed to correct native token properties for %s: %v", network.Name, err)
+		return
+	}
+	log.Printf("Corrected %s token properties from %d/%q to %d/%q", network.Name, network.Decimals, network.Symbol.String, decimals, symbol)
+}
@@ -0,0 +1,129 @@
+package networks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ProxyAnnouncement is a call a delegate announced on behalf of an account.
+// The delegate can execute it once the proxy's delay has passed.
+type ProxyAnnouncement struct {
+	Delegate     string
+	CallHash     string
+	AnnouncedAt  uint32
+	ExecutableAt uint32
+}
+
+// encodeAccount formats raw account bytes: hex for 20-byte EVM accounts,
+// SS58 otherwise
+func encodeAccount(raw []byte, prefix uint16) string {
+	if len(raw) == 20 {
+		return "0x" + hex.EncodeToString(raw)
+	}
+	return encodeSS58(raw, prefix)
+}
+
+// GetProxyAnnouncements returns the pending announcements made on behalf of
+// the account. Announcements are stored under the announcing delegate, so
+// the account's delegates are read from Proxy.Proxies and each delegate's
+// Proxy.Announcements is filtered for this account. It returns nil when the
+// network has no Proxy pallet.
+func (m *Manager) GetProxyAnnouncements(networkName, address string) ([]ProxyAnnouncement, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Proxy") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := accountKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	read := func(item string, who []byte) ([]byte, error) {
+		key, err := gstypes.CreateStorageKey(meta, "Proxy", item, who)
+		if err != nil {
+			return nil, err
+		}
+		var raw gstypes.StorageDataRaw
+		if _, err := getStorage(api, key, &raw, at); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	}
+
+	// Proxies(real) is (Vec<ProxyDefinition>, deposit); a ProxyDefinition is
+	// the delegate, a one-byte proxy type and the delay in blocks
+	raw, err := read("Proxies", account)
+	if err != nil {
+		return nil, err
+	}
+	count, n := decodeCompact(raw)
+	raw = raw[n:]
+
+	delays := make(map[string]uint32)
+	var delegates [][]byte
+	size := len(account) + 1 + 4
+	for i := uint64(0); i < count && len(raw) >= size; i++ {
+		delegate := raw[:len(account)]
+		delay := binary.LittleEndian.Uint32(raw[len(account)+1 : size])
+		// A delegate may hold several proxy types; the shortest delay
+		// applies
+		if current, seen := delays[string(delegate)]; !seen {
+			delegates = append(delegates, delegate)
+			delays[string(delegate)] = delay
+		} else if delay < current {
+			delays[string(delegate)] = delay
+		}
+		raw = raw[size:]
+	}
+
+	var announcements []ProxyAnnouncement
+	for _, delegate := range delegates {
+		// Announcements(delegate) is (Vec<Announcement>, deposit); an
+		// Announcement is the real account, the call hash and the height
+		raw, err := read("Announcements", delegate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read announcements of %s: %w", encodeAccount(delegate, network.SS58Prefix), err)
+		}
+		count, n := decodeCompact(raw)
+		raw = raw[n:]
+
+		size := len(account) + 32 + 4
+		for i := uint64(0); i < count && len(raw) >= size; i++ {
+			if bytes.Equal(raw[:len(account)], account) {
+				height := binary.LittleEndian.Uint32(raw[len(account)+32 : size])
+				announcements = append(announcements, ProxyAnnouncement{
+					Delegate:     encodeAccount(delegate, network.SS58Prefix),
+					CallHash:     "0x" + hex.EncodeToString(raw[len(account):len(account)+32]),
+					AnnouncedAt:  height,
+					ExecutableAt: height + delays[string(delegate)],
+				})
+			}
+			raw = raw[size:]
+		}
+	}
+
+	return announcements, nil
+}
@@ -0,0 +1,73 @@
+package networks
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// readTarget is the block a network's reads target: the best head, or the
+// finalized head plus lag blocks (capped at the best head)
+type readTarget struct {
+	best bool
+	lag  uint64
+}
+
+// parseReadTarget parses networks.read_target: "best", "finalized" or
+// "finalized+N"
+func parseReadTarget(s string) (readTarget, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "best":
+		return readTarget{best: true}, nil
+	case "finalized":
+		return readTarget{}, nil
+	}
+
+	rest, ok := strings.CutPrefix(s, "finalized+")
+	if !ok {
+		return readTarget{}, fmt.Errorf("expected best, finalized or finalized+N, got %q", s)
+	}
+	lag, err := strconv.ParseUint(strings.TrimSpace(rest), 10, 32)
+	if err != nil {
+		return readTarget{}, fmt.Errorf("invalid block count in %q: %w", s, err)
+	}
+	return readTarget{lag: lag}, nil
+}
+
+// networkReadTarget returns the network's read_target, falling back to the
+// UseFinalizedHead setting when it is unset or invalid
+func (m *Manager) networkReadTarget(networkName string) readTarget {
+	fallback := readTarget{best: !m.config.Load().UseFinalizedHead}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil || !network.ReadTarget.Valid || strings.TrimSpace(network.ReadTarget.String) == "" {
+		return fallback
+	}
+	target, err := parseReadTarget(network.ReadTarget.String)
+	if err != nil {
+		log.Printf("WARNING: invalid read_target for %s: %v", networkName, err)
+		return fallback
+	}
+	return target
+}
+
+// laggedHead returns the hash of the block lag blocks past the finalized
+// head, or of the best head if that is closer
+func laggedHead(api *gsrpc.SubstrateAPI, finalized gstypes.Hash, lag uint64) (gstypes.Hash, error) {
+	header, err := api.RPC.Chain.GetHeader(finalized)
+	if err != nil {
+		return gstypes.Hash{}, err
+	}
+	best, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return gstypes.Hash{}, err
+	}
+
+	number := min(uint64(header.Number)+lag, uint64(best.Number))
+	return api.RPC.Chain.GetBlockHash(number)
+}
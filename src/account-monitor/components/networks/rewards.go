@@ -0,0 +1,219 @@
+package networks
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Reward kinds reported by ScanRewards
+const (
+	RewardStaking          = "staking"
+	RewardPool             = "pool"
+	RewardParachainStaking = "parachain_staking"
+	RewardChildBounty      = "child_bounty"
+)
+
+// RewardEvent is a payout to a watched account found in a block's events.
+// Account is the public key hex, as returned by PublicKeyHex.
+type RewardEvent struct {
+	Kind    string
+	Account string
+	Amount  *big.Int
+	Block   uint64
+}
+
+// rewardEvents maps the payout events to their kind and the beneficiary and
+// amount fields, with the positions used by runtimes without field names
+var rewardEvents = map[string]struct {
+	kind         string
+	account      string
+	accountIndex int
+	amount       string
+	amountIndex  int
+}{
+	"Staking.Rewarded":          {kind: RewardStaking, account: "stash", amount: "amount", amountIndex: 1},
+	"NominationPools.PaidOut":   {kind: RewardPool, account: "member", amount: "payout", amountIndex: 2},
+	"ParachainStaking.Rewarded": {kind: RewardParachainStaking, account: "account", amount: "rewards", amountIndex: 1},
+	"ChildBounties.Claimed":     {kind: RewardChildBounty, account: "beneficiary", accountIndex: 3, amount: "payout", amountIndex: 2},
+}
+
+// Transfer is a Balances.Transfer event of at least the requested amount,
+// between any accounts. From and To are SS58 addresses.
+type Transfer struct {
+	From   string
+	To     string
+	Amount *big.Int
+	Block  uint64
+}
+
+// EventScan is what ScanEvents found for watched accounts
+type EventScan struct {
+	Rewards []RewardEvent
+	// AssetTraps are the traps still unclaimed at the read head
+	AssetTraps []AssetTrap
+	// Transfers of at least minTransfer, whoever sent them
+	Transfers []Transfer
+}
+
+// ScanEvents reads the events of the blocks after the given block up to the
+// read head and returns the payouts to and asset traps of watched accounts,
+// along with the last block scanned. With a non-nil minTransfer, native
+// transfers of at least that many plancks are returned too. At most
+// maxBlocks blocks are read; when more are pending, or on the first scan
+// (after == 0), only the latest maxBlocks are.
+func (m *Manager) ScanEvents(ctx context.Context, networkName string, after, maxBlocks uint64,
+	watched map[string]bool, minTransfer *big.Int) (EventScan, uint64, error) {
+
+	var scan EventScan
+
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return scan, after, err
+	}
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return scan, after, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return scan, after, err
+	}
+	var header *gstypes.Header
+	if at != nil {
+		header, err = api.RPC.Chain.GetHeader(*at)
+	} else {
+		header, err = api.RPC.Chain.GetHeaderLatest()
+	}
+	if err != nil {
+		return scan, after, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+	head := uint64(header.Number)
+	if head <= after {
+		return scan, after, nil
+	}
+
+	from := after + 1
+	if maxBlocks > 0 && head-after > maxBlocks {
+		from = head - maxBlocks + 1
+		if after > 0 {
+			log.Printf("Event scan on %s skipping blocks %d-%d (more than %d behind)", networkName, after+1, from-1, maxBlocks)
+		}
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return scan, after, err
+	}
+	key, err := gstypes.CreateStorageKey(meta, "System", "Events")
+	if err != nil {
+		return scan, after, err
+	}
+
+	// Runtimes seen during the scan, latest first
+	metas := []*gstypes.Metadata{meta}
+
+	for block := from; block <= head; block++ {
+		select {
+		case <-ctx.Done():
+			return scan, block - 1, ctx.Err()
+		default:
+		}
+
+		hash, err := api.RPC.Chain.GetBlockHash(block)
+		if err != nil {
+			return scan, block - 1, fmt.Errorf("%w: failed to get hash of block %d: %w", ErrRPCUnavailable, block, err)
+		}
+		var raw gstypes.StorageDataRaw
+		if _, err := api.RPC.State.GetStorage(key, &raw, hash); err != nil {
+			return scan, block - 1, fmt.Errorf("%w: failed to read events of block %d: %w", ErrRPCUnavailable, block, err)
+		}
+
+		var events []ChainEvent
+		for _, meta := range metas {
+			if events, err = decodeEvents(meta, raw); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			// Blocks before a runtime upgrade need that runtime's types
+			older, metaErr := api.RPC.State.GetMetadata(hash)
+			if metaErr != nil {
+				return scan, block - 1, fmt.Errorf("%w: failed to read metadata at block %d: %w", ErrRPCUnavailable, block, metaErr)
+			}
+			metas = append(metas, older)
+			if events, err = decodeEvents(older, raw); err != nil {
+				log.Printf("Skipping undecodable events of block %d on %s: %v", block, networkName, err)
+				continue
+			}
+		}
+
+		for _, event := range events {
+			if trap, ok := assetTrap(event, block, watched); ok {
+				scan.AssetTraps = append(scan.AssetTraps, trap)
+				continue
+			}
+			if minTransfer != nil {
+				if transfer, ok := largeTransfer(event, block, minTransfer, network.SS58Prefix); ok {
+					scan.Transfers = append(scan.Transfers, transfer)
+					continue
+				}
+			}
+
+			spec, ok := rewardEvents[event.Pallet+"."+event.Name]
+			if !ok {
+				continue
+			}
+			account, ok := event.Field(spec.account, spec.accountIndex).([]byte)
+			if !ok {
+				continue
+			}
+			accountHex := codec.HexEncodeToString(account)
+			if !watched[accountHex] {
+				continue
+			}
+			amount, ok := event.Field(spec.amount, spec.amountIndex).(*big.Int)
+			if !ok {
+				continue
+			}
+			scan.Rewards = append(scan.Rewards, RewardEvent{Kind: spec.kind, Account: accountHex, Amount: amount, Block: block})
+		}
+	}
+
+	// Traps claimed since they were recorded need no alert
+	traps := scan.AssetTraps[:0]
+	for _, trap := range scan.AssetTraps {
+		trapped, err := assetTrapped(api, meta, trap.Pallet, trap.Hash, at)
+		if err != nil {
+			log.Printf("Failed to read asset trap %s on %s: %v", trap.Hash, networkName, err)
+		}
+		if trapped || err != nil {
+			traps = append(traps, trap)
+		}
+	}
+	scan.AssetTraps = traps
+
+	return scan, head, nil
+}
+
+// largeTransfer returns a Balances.Transfer event moving at least min
+func largeTransfer(event ChainEvent, block uint64, min *big.Int, prefix uint16) (Transfer, bool) {
+	if event.Pallet != "Balances" || event.Name != "Transfer" {
+		return Transfer{}, false
+	}
+	amount, ok := event.Field("amount", 2).(*big.Int)
+	if !ok || amount.Cmp(min) < 0 {
+		return Transfer{}, false
+	}
+	from, fromOK := event.Field("from", 0).([]byte)
+	to, toOK := event.Field("to", 1).([]byte)
+	if !fromOK || !toOK {
+		return Transfer{}, false
+	}
+	return Transfer{From: encodeSS58(from, prefix), To: encodeSS58(to, prefix), Amount: amount, Block: block}, true
+}
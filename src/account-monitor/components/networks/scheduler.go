@@ -0,0 +1,217 @@
+package networks
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"time"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ScheduledTask is a Scheduler.Agenda entry that references a watched
+// account, either as the origin it dispatches from or inside its call.
+// Account is the public key hex.
+type ScheduledTask struct {
+	Account string
+	Block   uint32
+	Index   int
+	// Call is "Pallet.call", or the preimage hash when the call is stored
+	// as a preimage
+	Call string
+	// Origin is set when the task dispatches as the account
+	Origin   bool
+	Periodic bool
+	// At estimates when Block is reached from the chain's block time
+	At time.Time
+}
+
+// GetScheduledTasks returns the scheduled calls of the network that
+// reference a watched account. Calls stored inline are searched for the
+// account; calls only stored as a preimage can only be matched by origin.
+// It returns nil when the network has no Scheduler pallet.
+func (m *Manager) GetScheduledTasks(ctx context.Context, networkName string, watched map[string]bool) ([]ScheduledTask, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Scheduler") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := meta.FindStorageEntryMetadata("Scheduler", "Agenda")
+	if err != nil {
+		return nil, err
+	}
+	v14, ok := entry.(gstypes.StorageEntryMetadataV14)
+	if !ok || !v14.Type.IsMap {
+		return nil, fmt.Errorf("unsupported Scheduler.Agenda metadata")
+	}
+
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+	current := uint32(header.Number)
+	blockTime := expectedBlockTime(meta)
+
+	// Key format: prefix(32) + twox64(block)(8) + block(4)
+	keys, err := m.storageKeys(ctx, api, storagePrefix("Scheduler", "Agenda", 0))
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []ScheduledTask
+	for _, key := range keys {
+		if len(key) < 44 {
+			continue
+		}
+		block := binary.LittleEndian.Uint32(key[40:44])
+
+		var raw gstypes.StorageDataRaw
+		if ok, err := getStorage(api, key, &raw, nil); err != nil {
+			return tasks, err
+		} else if !ok {
+			continue
+		}
+
+		r := &scaleReader{types: meta.AsMetadataV14.EfficientLookup, raw: raw}
+		value, err := r.decode(v14.Type.AsMap.Value.Int64())
+		if err != nil {
+			log.Printf("Skipping undecodable agenda of block %d on %s: %v", block, networkName, err)
+			continue
+		}
+		agenda, _ := value.([]any)
+
+		at := time.Now()
+		if block > current {
+			at = at.Add(time.Duration(block-current) * blockTime)
+		}
+		for index, item := range agenda {
+			// Agenda slots are Option<Scheduled>; None is a cancelled task
+			slot, ok := item.(scaleVariant)
+			if !ok || slot.Name != "Some" || len(slot.Fields) != 1 {
+				continue
+			}
+			scheduled, ok := slot.Fields[0].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			call, inline := scheduledCall(meta, scheduled["call"])
+			periodic := false
+			if p, ok := scheduled["maybe_periodic"].(scaleVariant); ok {
+				periodic = p.Name == "Some"
+			}
+
+			origins := make(map[string]bool)
+			for _, id := range accountIDs(scheduled["origin"]) {
+				origins[codec.HexEncodeToString(id)] = true
+			}
+			for account := range watched {
+				origin := origins[account]
+				if !origin {
+					key, err := codec.HexDecodeString(account)
+					if err != nil || inline == nil || !bytes.Contains(inline, key) {
+						continue
+					}
+				}
+				tasks = append(tasks, ScheduledTask{
+					Account:  account,
+					Block:    block,
+					Index:    index,
+					Call:     call,
+					Origin:   origin,
+					Periodic: periodic,
+					At:       at,
+				})
+			}
+		}
+	}
+
+	return tasks, nil
+}
+
+// scheduledCall describes a scheduled Bounded<Call> and returns its encoded
+// call when it is stored inline
+func scheduledCall(meta *gstypes.Metadata, value any) (string, []byte) {
+	bounded, ok := value.(scaleVariant)
+	if !ok || len(bounded.Fields) == 0 {
+		return "unknown call", nil
+	}
+	switch bounded.Name {
+	case "Inline":
+		encoded, _ := bounded.Fields[0].([]byte)
+		return callName(meta, encoded), encoded
+	default:
+		// Legacy and Lookup hold the preimage hash first
+		if hash, ok := bounded.Fields[0].([]byte); ok {
+			return "preimage " + codec.HexEncodeToString(hash), nil
+		}
+		return "unknown call", nil
+	}
+}
+
+// callName returns "Pallet.call" for an encoded call, from its pallet and
+// call indexes
+func callName(meta *gstypes.Metadata, encoded []byte) string {
+	if len(encoded) < 2 {
+		return "unknown call"
+	}
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasCalls || byte(pallet.Index) != encoded[0] {
+			continue
+		}
+		if t, ok := meta.AsMetadataV14.EfficientLookup[pallet.Calls.Type.Int64()]; ok && t.Def.IsVariant {
+			for _, variant := range t.Def.Variant.Variants {
+				if byte(variant.Index) == encoded[1] {
+					return fmt.Sprintf("%s.%s", pallet.Name, variant.Name)
+				}
+			}
+		}
+		return fmt.Sprintf("%s.call_%d", pallet.Name, encoded[1])
+	}
+	return fmt.Sprintf("call %d.%d", encoded[0], encoded[1])
+}
+
+// accountIDs returns the 32-byte values in a decoded value, e.g. the
+// account of a Signed origin
+func accountIDs(value any) [][]byte {
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 32 {
+			return [][]byte{v}
+		}
+	case scaleVariant:
+		var ids [][]byte
+		for _, field := range v.Fields {
+			ids = append(ids, accountIDs(field)...)
+		}
+		return ids
+	case map[string]any:
+		var ids [][]byte
+		for _, field := range v {
+			ids = append(ids, accountIDs(field)...)
+		}
+		return ids
+	case []any:
+		var ids [][]byte
+		for _, field := range v {
+			ids = append(ids, accountIDs(field)...)
+		}
+		return ids
+	}
+	return nil
+}
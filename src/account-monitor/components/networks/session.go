@@ -0,0 +1,72 @@
+package networks
+
+import (
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// SessionKeys is a validator's standing in pallet-session
+type SessionKeys struct {
+	// NextKeys is the hex of the keys queued for the next session, empty
+	// when none are set
+	NextKeys string
+	// Active is set while the validator is in Session.Validators
+	Active bool
+}
+
+// GetSessionKeys reads Session.NextKeys and Session.Validators for a
+// validator stash. It returns nil when the network has no Session pallet.
+func (m *Manager) GetSessionKeys(networkName, address string) (*SessionKeys, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Session") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := accountKey(address)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &SessionKeys{}
+
+	storageKey, err := gstypes.CreateStorageKey(meta, "Session", "NextKeys", key)
+	if err != nil {
+		return nil, err
+	}
+	var raw gstypes.StorageDataRaw
+	if ok, err := getStorage(api, storageKey, &raw, at); err != nil {
+		return nil, err
+	} else if ok {
+		keys.NextKeys = codec.HexEncodeToString(raw)
+	}
+
+	storageKey, err = gstypes.CreateStorageKey(meta, "Session", "Validators")
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := getStorage(api, storageKey, &raw, at); err != nil {
+		return nil, err
+	} else if ok {
+		keys.Active = vecContains(raw, key)
+	}
+
+	return keys, nil
+}
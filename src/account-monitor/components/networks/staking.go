@@ -0,0 +1,257 @@
+package networks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// PendingRewardEstimate is a forward-looking estimate of a validator's
+// reward for the active era, based on its share of reward points so far and
+// the previous era's total validator payout
+type PendingRewardEstimate struct {
+	Era         uint32
+	Points      uint32
+	TotalPoints uint32
+	// PreviousPayout is Staking.ErasValidatorReward for the previous era
+	PreviousPayout *big.Int
+	// Estimate is the validator's (and its nominators') projected reward
+	Estimate *big.Int
+}
+
+// EstimatePendingReward projects the active era reward for a validator stash.
+// The active era's payout is only known once the era ends, so the previous
+// era's payout is used as the expected total. It returns nil when the
+// network has no Staking pallet.
+func (m *Manager) EstimatePendingReward(networkName, stash string) (*PendingRewardEstimate, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Staking") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	stashID, err := decodeAddress(stash)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := gstypes.CreateStorageKey(meta, "Staking", "ActiveEra")
+	if err != nil {
+		return nil, err
+	}
+	var activeEra struct {
+		Index gstypes.U32
+		Start gstypes.OptionU64
+	}
+	ok, err := getStorage(api, key, &activeEra, at)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || activeEra.Index == 0 {
+		return nil, fmt.Errorf("no active era on %s", networkName)
+	}
+	era := uint32(activeEra.Index)
+
+	key, err = gstypes.CreateStorageKey(meta, "Staking", "ErasRewardPoints", eraKey(era))
+	if err != nil {
+		return nil, err
+	}
+	var points struct {
+		Total      gstypes.U32
+		Individual []struct {
+			Validator gstypes.AccountID
+			Points    gstypes.U32
+		}
+	}
+	if _, err := getStorage(api, key, &points, at); err != nil {
+		return nil, fmt.Errorf("failed to read reward points for era %d: %w", era, err)
+	}
+
+	estimate := &PendingRewardEstimate{
+		Era:            era,
+		TotalPoints:    uint32(points.Total),
+		PreviousPayout: big.NewInt(0),
+		Estimate:       big.NewInt(0),
+	}
+	for _, p := range points.Individual {
+		if p.Validator == stashID {
+			estimate.Points = uint32(p.Points)
+			break
+		}
+	}
+
+	key, err = gstypes.CreateStorageKey(meta, "Staking", "ErasValidatorReward", eraKey(era-1))
+	if err != nil {
+		return nil, err
+	}
+	var payout gstypes.U128
+	ok, err = getStorage(api, key, &payout, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validator reward for era %d: %w", era-1, err)
+	}
+	if ok {
+		estimate.PreviousPayout = payout.Int
+	}
+
+	if estimate.TotalPoints > 0 && estimate.Points > 0 {
+		estimate.Estimate = new(big.Int).Div(
+			new(big.Int).Mul(estimate.PreviousPayout, big.NewInt(int64(estimate.Points))),
+			big.NewInt(int64(estimate.TotalPoints)))
+	}
+
+	return estimate, nil
+}
+
+func eraKey(era uint32) []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, era)
+	return b
+}
+
+// StakingRoles describes the staking roles an account currently holds
+type StakingRoles struct {
+	Validator bool
+	Nominator bool
+	Collator  bool
+	// Controller is the SS58 controller for a bonded stash, empty otherwise
+	Controller string
+}
+
+// GetStakingRoles checks Staking.Validators, Staking.Nominators, the
+// CollatorSelection invulnerables/candidates and ParachainStaking candidates
+// for the account
+func (m *Manager) GetStakingRoles(networkName, address string) (StakingRoles, error) {
+	var roles StakingRoles
+
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return roles, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return roles, err
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return roles, err
+	}
+
+	key, err := accountKey(address)
+	if err != nil {
+		return roles, err
+	}
+
+	at, err := m.readAt(networkName, api)
+	if err != nil {
+		return roles, err
+	}
+
+	// exists reports whether a storage map entry is present
+	exists := func(pallet, item string, args ...[]byte) (bool, error) {
+		key, err := gstypes.CreateStorageKey(meta, pallet, item, args...)
+		if err != nil {
+			return false, err
+		}
+		var raw gstypes.StorageDataRaw
+		return getStorage(api, key, &raw, at)
+	}
+
+	// ParachainStaking chains may use 20-byte EVM accounts; the other
+	// pallets only know 32-byte accounts
+	if m.hasPallet(network.ID, "ParachainStaking") {
+		if roles.Collator, err = exists("ParachainStaking", "CandidateInfo", key); err != nil || roles.Collator {
+			return roles, err
+		}
+	}
+	if len(key) != len(gstypes.AccountID{}) {
+		return roles, nil
+	}
+	var accountID gstypes.AccountID
+	copy(accountID[:], key)
+
+	if m.hasPallet(network.ID, "Staking") {
+		if roles.Validator, err = exists("Staking", "Validators", accountID[:]); err != nil {
+			return roles, err
+		}
+		if roles.Nominator, err = exists("Staking", "Nominators", accountID[:]); err != nil {
+			return roles, err
+		}
+
+		key, err := gstypes.CreateStorageKey(meta, "Staking", "Bonded", accountID[:])
+		if err != nil {
+			return roles, err
+		}
+		var controller gstypes.AccountID
+		ok, err := getStorage(api, key, &controller, at)
+		if err != nil {
+			return roles, err
+		}
+		if ok {
+			roles.Controller = encodeSS58(controller[:], network.SS58Prefix)
+		}
+	}
+
+	if !m.hasPallet(network.ID, "CollatorSelection") {
+		return roles, nil
+	}
+
+	invulnerablesKey, err := gstypes.CreateStorageKey(meta, "CollatorSelection", "Invulnerables")
+	if err != nil {
+		return roles, err
+	}
+	var invulnerables []gstypes.AccountID
+	if _, err := getStorage(api, invulnerablesKey, &invulnerables, at); err != nil {
+		return roles, err
+	}
+	for _, id := range invulnerables {
+		if id == accountID {
+			roles.Collator = true
+		}
+	}
+
+	if !roles.Collator {
+		// Renamed from Candidates to CandidateList in newer runtimes
+		for _, item := range []string{"CandidateList", "Candidates"} {
+			key, err := gstypes.CreateStorageKey(meta, "CollatorSelection", item)
+			if err != nil {
+				continue
+			}
+			var candidates []struct {
+				Who     gstypes.AccountID
+				Deposit gstypes.U128
+			}
+			if _, err := getStorage(api, key, &candidates, at); err != nil {
+				return roles, err
+			}
+			for _, c := range candidates {
+				if c.Who == accountID {
+					roles.Collator = true
+				}
+			}
+			break
+		}
+	}
+
+	return roles, nil
+}
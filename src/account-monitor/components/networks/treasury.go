@@ -0,0 +1,150 @@
+package networks
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// Fallback block time when the runtime exposes neither Babe nor Aura timing
+const defaultBlockTime = 6 * time.Second
+
+// TreasuryStatus is the treasury pot and the burn projected for the next
+// spend period. The projection assumes nothing is spent before then, so it
+// is an upper bound.
+type TreasuryStatus struct {
+	// Account is the treasury's hex account id
+	Account string
+	// Pot is the treasury's free balance above the existential deposit
+	Pot           *big.Int
+	BurnPermill   uint32
+	ProjectedBurn *big.Int
+	SpendPeriod   uint32
+	// NextSpendBlock is the block at which the burn is applied
+	NextSpendBlock uint32
+	NextSpendAt    time.Time
+}
+
+// PalletAccount returns the hex account id of a pallet's account: "modl"
+// followed by the 8-byte pallet id, zero padded to 32 bytes
+func PalletAccount(palletID [8]byte) string {
+	var accountID [32]byte
+	copy(accountID[:], "modl")
+	copy(accountID[4:12], palletID[:])
+
+	return "0x" + hex.EncodeToString(accountID[:])
+}
+
+// GetTreasuryStatus reads the treasury pot, the Burn and SpendPeriod
+// constants and the next spend period. It returns nil when the network has
+// no Treasury pallet.
+func (m *Manager) GetTreasuryStatus(networkName string) (*TreasuryStatus, error) {
+	api, err := m.getClient(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := m.getNetwork(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if !m.hasPallet(network.ID, "Treasury") {
+		return nil, nil
+	}
+
+	meta, err := latestMetadata(api)
+	if err != nil {
+		return nil, err
+	}
+
+	rawID, err := meta.FindConstantValue("Treasury", "PalletId")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Treasury.PalletId: %w", err)
+	}
+	var palletID [8]byte
+	if err := codec.Decode(rawID, &palletID); err != nil {
+		return nil, fmt.Errorf("failed to decode Treasury.PalletId: %w", err)
+	}
+
+	var burn, spendPeriod uint32
+	for _, c := range []struct {
+		name   string
+		target *uint32
+	}{{"Burn", &burn}, {"SpendPeriod", &spendPeriod}} {
+		raw, err := meta.FindConstantValue("Treasury", c.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Treasury.%s: %w", c.name, err)
+		}
+		if err := codec.Decode(raw, c.target); err != nil {
+			return nil, fmt.Errorf("failed to decode Treasury.%s: %w", c.name, err)
+		}
+	}
+	if spendPeriod == 0 {
+		return nil, fmt.Errorf("treasury spend period is zero on %s", networkName)
+	}
+
+	status := &TreasuryStatus{
+		Account:     PalletAccount(palletID),
+		BurnPermill: burn,
+		SpendPeriod: spendPeriod,
+	}
+
+	balance, err := m.GetBalance(networkName, status.Account)
+	if err != nil {
+		return nil, err
+	}
+	ed, err := m.GetExistentialDeposit(networkName)
+	if err != nil {
+		ed = big.NewInt(0)
+	}
+	status.Pot = new(big.Int)
+	if balance.Free != nil && balance.Free.Cmp(ed) > 0 {
+		status.Pot.Sub(balance.Free, ed)
+	}
+	status.ProjectedBurn = new(big.Int).Mul(status.Pot, big.NewInt(int64(burn)))
+	status.ProjectedBurn.Quo(status.ProjectedBurn, big.NewInt(1_000_000))
+
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+	}
+	current := uint32(header.Number)
+
+	// Newer runtimes record the last spend period; older ones spend on
+	// multiples of SpendPeriod
+	status.NextSpendBlock = (current/spendPeriod + 1) * spendPeriod
+	if key, err := gstypes.CreateStorageKey(meta, "Treasury", "LastSpendPeriod"); err == nil {
+		var raw gstypes.StorageDataRaw
+		if ok, err := api.RPC.State.GetStorageLatest(key, &raw); err == nil && ok && len(raw) >= 4 {
+			last := binary.LittleEndian.Uint32(raw[:4])
+			for status.NextSpendBlock = last + spendPeriod; status.NextSpendBlock <= current; {
+				status.NextSpendBlock += spendPeriod
+			}
+		}
+	}
+
+	status.NextSpendAt = time.Now().Add(time.Duration(status.NextSpendBlock-current) * expectedBlockTime(meta))
+
+	return status, nil
+}
+
+// expectedBlockTime returns the chain's target block time from the Babe or
+// Aura constants, or defaultBlockTime
+func expectedBlockTime(meta *gstypes.Metadata) time.Duration {
+	for _, c := range []struct{ pallet, name string }{{"Babe", "ExpectedBlockTime"}, {"Aura", "SlotDuration"}} {
+		raw, err := meta.FindConstantValue(c.pallet, c.name)
+		if err != nil {
+			continue
+		}
+		var millis uint64
+		if err := codec.Decode(raw, &millis); err == nil && millis > 0 {
+			return time.Duration(millis) * time.Millisecond
+		}
+	}
+	return defaultBlockTime
+}
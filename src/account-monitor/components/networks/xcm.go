@@ -0,0 +1,112 @@
+package networks
+
+import (
+	"fmt"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gstypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// AssetTrap is an AssetsTrapped event whose origin is a watched account:
+// assets from a failed XCM execution, claimable with claim_assets while
+// AssetTraps holds the hash. Account is the public key hex.
+type AssetTrap struct {
+	Pallet  string
+	Account string
+	Hash    string
+	Assets  int
+	Block   uint64
+}
+
+// xcmPallets are the names the XCM pallet is deployed under: XcmPallet on
+// relay chains, PolkadotXcm on parachains
+var xcmPallets = map[string]bool{"PolkadotXcm": true, "XcmPallet": true}
+
+// assetTrap returns the trap recorded by an AssetsTrapped event when its
+// origin location ends in a watched AccountId32 junction
+func assetTrap(event ChainEvent, block uint64, watched map[string]bool) (AssetTrap, bool) {
+	if !xcmPallets[event.Pallet] || event.Name != "AssetsTrapped" {
+		return AssetTrap{}, false
+	}
+	hash, ok := event.Field("hash", 0).([]byte)
+	if !ok {
+		return AssetTrap{}, false
+	}
+	account := locationAccount(event.Field("origin", 1))
+	if account == nil || !watched[codec.HexEncodeToString(account)] {
+		return AssetTrap{}, false
+	}
+
+	return AssetTrap{
+		Pallet:  event.Pallet,
+		Account: codec.HexEncodeToString(account),
+		Hash:    codec.HexEncodeToString(hash),
+		Assets:  versionedAssetCount(event.Field("assets", 2)),
+		Block:   block,
+	}, true
+}
+
+// locationAccount returns the id of the AccountId32 junction in a decoded
+// XCM location, or nil. Junctions are nested differently across XCM
+// versions, so the whole value is searched.
+func locationAccount(value any) []byte {
+	switch v := value.(type) {
+	case scaleVariant:
+		if v.Name == "AccountId32" {
+			id, _ := ChainEvent{Fields: v.Fields, Names: v.Names}.Field("id", 1).([]byte)
+			if len(id) == 32 {
+				return id
+			}
+			return nil
+		}
+		for _, field := range v.Fields {
+			if id := locationAccount(field); id != nil {
+				return id
+			}
+		}
+	case map[string]any:
+		for _, field := range v {
+			if id := locationAccount(field); id != nil {
+				return id
+			}
+		}
+	case []any:
+		for _, field := range v {
+			if id := locationAccount(field); id != nil {
+				return id
+			}
+		}
+	}
+	return nil
+}
+
+// versionedAssetCount returns the number of assets in a decoded
+// VersionedAssets (V3, V4 or V5), or 0 if unknown
+func versionedAssetCount(value any) int {
+	versioned, ok := value.(scaleVariant)
+	if !ok || len(versioned.Fields) != 1 {
+		return 0
+	}
+	assets, _ := versioned.Fields[0].([]any)
+	return len(assets)
+}
+
+// assetTrapped reports whether the pallet's AssetTraps still holds the hash,
+// i.e. the assets haven't been claimed
+func assetTrapped(api *gsrpc.SubstrateAPI, meta *gstypes.Metadata, pallet, hash string, at *gstypes.Hash) (bool, error) {
+	raw, err := codec.HexDecodeString(hash)
+	if err != nil {
+		return false, err
+	}
+	key, err := gstypes.CreateStorageKey(meta, pallet, "AssetTraps", raw)
+	if err != nil {
+		return false, fmt.Errorf("%s.AssetTraps: %w", pallet, err)
+	}
+	var count gstypes.U32
+	ok, err := getStorage(api, key, &count, at)
+	if err != nil {
+		return false, err
+	}
+	return ok && count > 0, nil
+}
@@ -18,8 +18,40 @@ type Network struct {
 	SS58Prefix       uint16
 	Active           bool
 	LastCheckedBlock uint64
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// ExistentialDeposit is the fallback ED (in plancks) used when the
+	// Balances.ExistentialDeposit constant can't be read from metadata
+	ExistentialDeposit sql.NullString
+	// ScanAssets, ScanForeignAssets and ScanPoolAssets override asset
+	// scanning for the network; NULL means scan whenever the pallet is
+	// detected
+	ScanAssets        sql.NullBool
+	ScanForeignAssets sql.NullBool
+	ScanPoolAssets    sql.NullBool
+	// EVMRPCURL is an optional Ethereum JSON-RPC endpoint; H160 accounts
+	// are read through it (eth_getBalance and ERC-20 balanceOf) instead of
+	// substrate storage
+	EVMRPCURL sql.NullString
+	// ReadTarget selects the block reads target: "best", "finalized" or
+	// "finalized+N"; NULL follows the use_finalized_head setting
+	ReadTarget sql.NullString
+	// SudoKey is the Sudo.Key account (hex) recorded by discovery, empty
+	// once sudo was removed; NULL until first read
+	SudoKey sql.NullString
+	// WhaleTransferThreshold, in whole tokens, enables alerts on any native
+	// transfer of at least that amount on the network; NULL disables
+	WhaleTransferThreshold sql.NullString
+	// AuthHeader holds "Name: value" header lines sent to private RPC
+	// endpoints. It is a secret and must never be logged.
+	AuthHeader sql.NullString
+	// TLSCAFile is a PEM bundle trusted instead of the system roots and
+	// TLSCertPin a SHA-256 SPKI pin checked against the endpoint's chain
+	TLSCAFile  sql.NullString
+	TLSCertPin sql.NullString
+	// GenesisHash is the chain's block 0 hash, recorded on first connect
+	// and checked on every reconnect
+	GenesisHash sql.NullString
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 type Account struct {
@@ -30,8 +62,68 @@ type Account struct {
 	Description    sql.NullString
 	MonitorEnabled bool
 	DiscordNotify  bool
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	NotifyMask     AlertType
+	// Profile selects the balance read path: ProfileFull or ProfileMinimal
+	Profile string
+	// Family labels accounts derived from one seed (//0, //1, ...); they are
+	// rolled up into one entry in the summary
+	Family sql.NullString
+	// LastChecked is when a balance read for the account last succeeded
+	LastChecked sql.NullTime
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Allowed values for Account.Profile. A minimal profile account is read
+// through System.Account only, at the finalized head with retries, and
+// skips asset scanning and staking lookups; meant for cold storage.
+const (
+	ProfileFull    = "full"
+	ProfileMinimal = "minimal"
+)
+
+// Allowed values for Account.AddressType. "substrate" is the legacy value for
+// an account whose crypto scheme is unspecified.
+const (
+	AddressTypeSubstrate = "substrate"
+	AddressTypeSr25519   = "sr25519"
+	AddressTypeEd25519   = "ed25519"
+	AddressTypeEcdsa     = "ecdsa"
+	AddressTypeEVM       = "evm"
+)
+
+// ValidAddressType reports whether t is one of the allowed address types
+func ValidAddressType(t string) bool {
+	switch t {
+	case AddressTypeSubstrate, AddressTypeSr25519, AddressTypeEd25519, AddressTypeEcdsa, AddressTypeEVM:
+		return true
+	}
+	return false
+}
+
+// AlertType is a bit in Account.NotifyMask selecting one kind of alert
+type AlertType uint32
+
+const (
+	AlertBalance AlertType = 1 << iota
+	AlertLowBalance
+	AlertSlash
+	AlertBounty
+	AlertProxy
+	AlertValidator
+	AlertIdentity
+	AlertGovernance
+	AlertApproval
+	AlertTreasury
+
+	AlertAll = AlertBalance | AlertLowBalance | AlertSlash | AlertBounty | AlertProxy | AlertValidator | AlertIdentity |
+		AlertGovernance | AlertApproval | AlertTreasury
+)
+
+// Notifies reports whether alerts of type t should be sent for the account.
+// DiscordNotify remains the master switch.
+func (a Account) Notifies(t AlertType) bool {
+	return a.DiscordNotify && a.NotifyMask&t != 0
 }
 
 type NetworkToken struct {
@@ -45,19 +137,55 @@ type NetworkToken struct {
 	PalletName sql.NullString
 	Metadata   sql.NullString
 	Active     bool
+	// SummaryMinBalance overrides the global summary_min_asset_balance for
+	// this token, in whole tokens
+	SummaryMinBalance sql.NullFloat64
 }
 
 type Balance struct {
-	ID         uint64
-	AccountID  uint
-	NetworkID  uint
-	TokenID    uint
-	Free       *big.Int
-	Reserved   *big.Int
-	MiscFrozen *big.Int
-	FeeFrozen  *big.Int
-	Bonded     *big.Int
-	Total      *big.Int
+	ID        uint64
+	AccountID uint
+	NetworkID uint
+	TokenID   uint
+	Free      *big.Int
+	Reserved  *big.Int
+	// Frozen is the part of Free held by locks and freezes, which can't be
+	// transferred; zero for assets
+	Frozen *big.Int
+	Bonded *big.Int
+	Total  *big.Int
+	// BondedBySource splits Bonded by staking pallet (Staking,
+	// NominationPools, ParachainStaking, DelegatedStaking); nil when nothing
+	// is bonded
+	BondedBySource map[string]*big.Int
+	// DelegatedTo is the agent (SS58) the account delegates Delegated to
+	// through DelegatedStaking; empty when it doesn't delegate
+	DelegatedTo string
+	Delegated   *big.Int
+	// Reference counters from System.Account; zero for assets
+	Nonce       uint32
+	Consumers   uint32
+	Providers   uint32
+	Sufficients uint32
+	// FreezeReason says why the whole native balance can't be moved (e.g.
+	// the chain is in safe mode); empty when it isn't frozen
+	FreezeReason string
+}
+
+// Spendable returns the free balance not held by freezes and locks, or zero
+// when the account is frozen
+func (b Balance) Spendable() *big.Int {
+	if b.FreezeReason != "" || b.Free == nil {
+		return big.NewInt(0)
+	}
+	spendable := new(big.Int).Set(b.Free)
+	if b.Frozen != nil {
+		spendable.Sub(spendable, b.Frozen)
+	}
+	if spendable.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return spendable
 }
 
 type BalanceChange struct {
@@ -77,6 +205,35 @@ type BalanceChange struct {
 	RecordedAt   time.Time
 }
 
+// FailedNotification is an alert that could not be delivered and is
+// waiting to be retried
+type FailedNotification struct {
+	ID            uint64
+	Type          string
+	Target        string
+	Content       string
+	FailureReason string
+	Attempts      uint
+	CreatedAt     time.Time
+	LastAttemptAt sql.NullTime
+}
+
+// BalancePoint is the total balance at a point in time
+type BalancePoint struct {
+	Time  time.Time
+	Total *big.Int
+}
+
+// HistorySeries identifies one account/network/token balance history
+type HistorySeries struct {
+	AccountID uint
+	NetworkID uint
+	Network   string
+	TokenID   uint
+	Symbol    string
+	Decimals  uint8
+}
+
 type Bounty struct {
 	ID             uint
 	NetworkID      uint
@@ -88,7 +245,12 @@ type Bounty struct {
 	Bond           *big.Int
 	Value          *big.Int
 	Status         string
-	Description    sql.NullString
+	// Status-specific data: Beneficiary and UnlockAt are set for
+	// pending_payout, UpdateDue for active
+	Beneficiary sql.NullString
+	UpdateDue   uint32
+	UnlockAt    uint32
+	Description sql.NullString
 }
 
 type ChildBounty struct {
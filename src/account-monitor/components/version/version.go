@@ -0,0 +1,41 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Build information, set at build time with
+//
+//	-ldflags "-X github.com/stake-plus/account-manager/src/account-monitor/components/version.Version=..."
+//
+// (see the Makefile). Builds without ldflags report "dev".
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// Info is the build information of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// String renders the build information on one line, e.g.
+// "v1.4.0 (commit 1a2b3c4, built 2024-05-01T12:00:00Z, go1.22.2)"
+func String() string {
+	info := Get()
+	return fmt.Sprintf("%s (commit %s, built %s, %s)", info.Version, info.Commit, info.BuildDate, info.GoVersion)
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	types "github.com/stake-plus/account-manager/src/account-monitor/components/types"
+)
+
+// importRecord is one account row from an import file
+type importRecord struct {
+	Line        int      `json:"-"`
+	Address     string   `json:"address"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+// runImport upserts the accounts listed in a CSV (address,name,description,tags)
+// or JSON file. Invalid rows are reported and skipped.
+func runImport(db *database.DB, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var records []importRecord
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		records, err = readImportJSON(f)
+	} else {
+		records, err = readImportCSV(f)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var added, updated, rejected int
+	for _, rec := range records {
+		if err := networks.ValidateAddress(rec.Address); err != nil {
+			log.Printf("Line %d: rejected %q: %v", rec.Line, rec.Address, err)
+			rejected++
+			continue
+		}
+
+		addressType := types.AddressTypeSubstrate
+		if networks.IsEVMAddress(rec.Address) {
+			addressType = types.AddressTypeEVM
+		}
+
+		inserted, err := db.UpsertAccount(strings.TrimSpace(rec.Address), addressType,
+			rec.Name, rec.Description, strings.Join(rec.Tags, ","))
+		if err != nil {
+			log.Printf("Line %d: failed to store %s: %v", rec.Line, rec.Address, err)
+			rejected++
+			continue
+		}
+
+		if inserted {
+			added++
+		} else {
+			updated++
+		}
+	}
+
+	log.Printf("Import complete: %d added, %d updated, %d rejected", added, updated, rejected)
+	return nil
+}
+
+func readImportCSV(r io.Reader) ([]importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records []importRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		// Skip blank lines, comments and an optional header row
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(fields[0]), "address") {
+			continue
+		}
+
+		rec := importRecord{Line: line, Address: strings.TrimSpace(fields[0])}
+		if len(fields) > 1 {
+			rec.Name = strings.TrimSpace(fields[1])
+		}
+		if len(fields) > 2 {
+			rec.Description = strings.TrimSpace(fields[2])
+		}
+		if len(fields) > 3 {
+			for _, tag := range strings.Split(fields[3], ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					rec.Tags = append(rec.Tags, tag)
+				}
+			}
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+func readImportJSON(r io.Reader) ([]importRecord, error) {
+	var records []importRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+	// JSON has no meaningful line numbers, report array positions instead
+	for i := range records {
+		records[i].Line = i + 1
+	}
+	return records, nil
+}
+
+// addSovereignAccount adds a parachain's sovereign account (on the relay
+// chain, or on sibling parachains when sibling is set) as a monitored account
+func addSovereignAccount(db *database.DB, paraIDStr string, sibling bool) error {
+	paraID, err := strconv.ParseUint(paraIDStr, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid para id %q: %w", paraIDStr, err)
+	}
+
+	address := networks.DeriveSovereignAccount(uint32(paraID), !sibling)
+	kind := "child"
+	if sibling {
+		kind = "sibling"
+	}
+	name := fmt.Sprintf("Para %d sovereign (%s)", paraID, kind)
+
+	inserted, err := db.UpsertAccount(address, types.AddressTypeSubstrate, name,
+		fmt.Sprintf("Sovereign account of parachain %d", paraID), "sovereign")
+	if err != nil {
+		return err
+	}
+
+	if inserted {
+		log.Printf("Added %s: %s", name, address)
+	} else {
+		log.Printf("Updated %s: %s", name, address)
+	}
+	return nil
+}
+
+// readFamilyFile reads one address per line, in derivation order (//0 first).
+// Blank lines and # comments are skipped.
+func readFamilyFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var addresses []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			addresses = append(addresses, line)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("%s lists no addresses", path)
+	}
+	return addresses, nil
+}
+
+// checkFamily validates a derivation family's address list, logging each
+// problem. It returns the addresses when the list is usable.
+func checkFamily(path string) ([]string, error) {
+	addresses, err := readFamilyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if errs := networks.ValidateFamily(addresses); len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("Rejected %v", err)
+		}
+		return nil, fmt.Errorf("%d problem(s) in %s", len(errs), path)
+	}
+	log.Printf("%d addresses in %s form a valid family", len(addresses), path)
+	return addresses, nil
+}
+
+// addFamily labels the listed addresses as one derivation family, adding
+// the ones not yet monitored as "<family> #<index>". Nothing is stored
+// unless the whole list is valid.
+func addFamily(db *database.DB, family, path string) error {
+	family = strings.TrimSpace(family)
+	if family == "" {
+		return fmt.Errorf("empty family label")
+	}
+	addresses, err := checkFamily(path)
+	if err != nil {
+		return err
+	}
+
+	var added, labeled int
+	for i, address := range addresses {
+		found, err := db.SetAccountFamily(address, family)
+		if err != nil {
+			return fmt.Errorf("failed to label %s: %w", address, err)
+		}
+		if found {
+			labeled++
+			continue
+		}
+
+		addressType := types.AddressTypeSubstrate
+		if networks.IsEVMAddress(address) {
+			addressType = types.AddressTypeEVM
+		}
+		if _, err := db.UpsertAccount(address, addressType, fmt.Sprintf("%s #%d", family, i), "", ""); err != nil {
+			return fmt.Errorf("failed to add %s: %w", address, err)
+		}
+		if _, err := db.SetAccountFamily(address, family); err != nil {
+			return fmt.Errorf("failed to label %s: %w", address, err)
+		}
+		added++
+	}
+
+	log.Printf("Family %s: %d accounts added, %d existing accounts labeled", family, added, labeled)
+	return nil
+}
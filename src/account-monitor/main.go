@@ -6,18 +6,21 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/stake-plus/account-manager/src/account-monitor/components/api"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
 	monitor "github.com/stake-plus/account-manager/src/account-monitor/components/monitor"
 	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/version"
 )
 
 func main() {
-	log.Println("Account Monitor starting...")
+	log.Printf("Account Monitor %s starting...", version.String())
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -47,8 +50,52 @@ func main() {
 		}
 	}()
 
+	// One-shot subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			if len(os.Args) < 3 {
+				log.Fatal("Usage: account-monitor import <accounts.csv|accounts.json>")
+			}
+			if err := runImport(db, os.Args[2]); err != nil {
+				log.Fatalf("Import failed: %v", err)
+			}
+			return
+		case "add-sovereign":
+			if len(os.Args) < 3 {
+				log.Fatal("Usage: account-monitor add-sovereign <para_id> [sibling]")
+			}
+			if err := addSovereignAccount(db, os.Args[2], len(os.Args) > 3 && os.Args[3] == "sibling"); err != nil {
+				log.Fatalf("Failed to add sovereign account: %v", err)
+			}
+			return
+		case "check-family":
+			if len(os.Args) < 3 {
+				log.Fatal("Usage: account-monitor check-family <addresses.txt>")
+			}
+			if _, err := checkFamily(os.Args[2]); err != nil {
+				log.Fatalf("Invalid family: %v", err)
+			}
+			return
+		case "add-family":
+			if len(os.Args) < 4 {
+				log.Fatal("Usage: account-monitor add-family <label> <addresses.txt>")
+			}
+			if err := addFamily(db, os.Args[2], os.Args[3]); err != nil {
+				log.Fatalf("Failed to add family: %v", err)
+			}
+			return
+		default:
+			log.Fatalf("Unknown command: %s", os.Args[1])
+		}
+	}
+
 	// Initialize Discord client
 	var discordClient *discord.Client
+	httpOptions := discord.HTTPOptions{
+		Timeout:  time.Duration(cfg.DiscordTimeoutSeconds) * time.Second,
+		ProxyURL: cfg.DiscordProxyURL,
+	}
 	if cfg.EnableNotifications {
 		if cfg.UseDiscordBot {
 			// Check if bot has proper permissions
@@ -67,13 +114,16 @@ func main() {
 				log.Printf("Make sure the bot is invited with proper permissions: %s", inviteURL)
 			}
 
-			discordClient, err = discord.NewBotClient(cfg.DiscordToken, cfg.AlertsChannelID, cfg.SummaryChannelID)
+			discordClient, err = discord.NewBotClient(cfg.DiscordToken, cfg.AlertsChannelID, cfg.SummaryChannelID, httpOptions)
 			if err != nil {
 				log.Printf("Failed to initialize Discord bot client: %v", err)
 				// Fall back to webhook if available
 				if cfg.DiscordWebhook != "" {
 					log.Println("Falling back to webhook client")
-					discordClient = discord.NewWebhookClient(cfg.DiscordWebhook, cfg.DiscordChannelID)
+					discordClient, err = discord.NewWebhookClient(cfg.DiscordWebhook, cfg.DiscordChannelID, httpOptions)
+					if err != nil {
+						log.Fatalf("Failed to initialize Discord webhook client: %v", err)
+					}
 				} else {
 					log.Println("Discord notifications disabled due to initialization failure")
 					cfg.EnableNotifications = false
@@ -84,20 +134,39 @@ func main() {
 				log.Printf("Summaries will be sent to channel: %s", cfg.SummaryChannelID)
 			}
 		} else if cfg.DiscordWebhook != "" {
-			discordClient = discord.NewWebhookClient(cfg.DiscordWebhook, cfg.DiscordChannelID)
+			discordClient, err = discord.NewWebhookClient(cfg.DiscordWebhook, cfg.DiscordChannelID, httpOptions)
+			if err != nil {
+				log.Fatalf("Failed to initialize Discord webhook client: %v", err)
+			}
 		}
 	}
 
+	discordClient.SetSummaryStyle(cfg.SummaryStyle)
+	discordClient.SetMessageLimit(cfg.MaxMessageLength)
+	discordClient.SetMaxConcurrentSends(cfg.MaxConcurrentNotifications)
+	discordClient.SetChangesChannel(cfg.ChangesChannelID)
+	discordClient.SetSummaryDelivery(cfg.SummaryRetryAttempts,
+		time.Duration(cfg.SummaryRetryBackoffSeconds)*time.Second, cfg.SummarySpoolDir)
+	discordClient.SetDeadLetterStore(db)
+	discordClient.EnableMuteCommands(db, cfg.GuildID, cfg.MonitorRoleID)
+	discordClient.LoadTemplates(cfg.NotificationTemplateDir)
+
+	// Settings read after startup go through live, which SIGHUP replaces
+	live := config.NewLive(cfg)
+
 	// Initialize network manager
 	log.Println("Initializing network manager...")
-	networkMgr, err := networks.NewManager(db, cfg)
+	networkMgr, err := networks.NewManager(db, live)
 	if err != nil {
 		log.Fatalf("Failed to initialize network manager: %v", err)
 	}
 
 	// Initialize monitor
 	log.Println("Initializing monitor...")
-	mon := monitor.New(db, networkMgr, discordClient, cfg)
+	mon := monitor.New(db, networkMgr, discordClient, live)
+	networkMgr.SetAssetChangeHandler(mon.HandleAssetChanges)
+	networkMgr.SetGenesisMismatchHandler(mon.HandleGenesisMismatch)
+	networkMgr.SetSudoKeyChangeHandler(mon.HandleSudoKeyChange)
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -114,6 +183,28 @@ func main() {
 		cancel()
 	}()
 
+	// Reload runtime settings on SIGHUP
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hupChan:
+				log.Println("Received SIGHUP, reloading settings...")
+				if err := config.Reload(live); err != nil {
+					log.Printf("Failed to reload settings: %v", err)
+				} else {
+					log.Println("Settings reloaded")
+				}
+			}
+		}
+	}()
+
+	warnIfUnconfigured(db, discordClient, cfg)
+
 	// Initial network discovery
 	log.Println("Starting initial network discovery...")
 	if err := networkMgr.DiscoverNetworks(ctx); err != nil {
@@ -127,8 +218,28 @@ func main() {
 	// Start monitoring loops
 	log.Println("Starting monitoring services...")
 
+	// The balance monitor and event dispatcher are waited for on shutdown
+	// so the alerts they hold are delivered
+	var services sync.WaitGroup
+
+	// Balance event dispatcher
+	services.Add(1)
+	go func() {
+		defer services.Done()
+		mon.Events().Run(ctx)
+	}()
+
+	// HTTP API
+	if cfg.APIListenAddr != "" {
+		server := api.NewServer(db, live)
+		server.SetBalanceGauges(mon.BalanceGauges())
+		go server.Start(ctx)
+	}
+
 	// Balance monitor
+	services.Add(1)
 	go func() {
+		defer services.Done()
 		defer func() {
 			if r := recover(); r != nil {
 				log.Printf("Balance monitor panic recovered: %v", r)
@@ -157,6 +268,30 @@ func main() {
 		mon.StartBountyMonitor(ctx, time.Duration(cfg.BountyCheckIntervalMinutes)*time.Minute)
 	}()
 
+	// Failed notification redelivery
+	if cfg.NotificationRetryMinutes > 0 {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Notification retry panic recovered: %v", r)
+				}
+			}()
+			mon.StartNotificationRetry(ctx, time.Duration(cfg.NotificationRetryMinutes)*time.Minute)
+		}()
+	}
+
+	// Expired mute cleanup
+	if cfg.MuteCleanupHours > 0 {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Mute cleanup panic recovered: %v", r)
+				}
+			}()
+			mon.StartMuteCleanup(ctx, time.Duration(cfg.MuteCleanupHours)*time.Hour)
+		}()
+	}
+
 	// Network refresh loop
 	go func() {
 		defer func() {
@@ -165,32 +300,44 @@ func main() {
 			}
 		}()
 
-		ticker := time.NewTicker(30 * time.Minute)
-		defer ticker.Stop()
+		runNetworkRefresh(ctx, networkMgr)
+	}()
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				log.Println("Refreshing network information...")
-				if err := networkMgr.DiscoverNetworks(ctx); err != nil {
-					if err != context.Canceled {
-						log.Printf("Network refresh error: %v", err)
-					}
-				}
-			}
+	if cfg.EnableNotifications && cfg.AnnounceStartup {
+		if err := discordClient.SendOperationalAlert("Account monitor started", "Version "+version.String()); err != nil {
+			log.Printf("Failed to send startup message: %v", err)
 		}
-	}()
+	}
 
 	log.Println("Account monitor is running. Press Ctrl+C to stop.")
 
 	// Wait for shutdown
 	<-ctx.Done()
 
-	// Give goroutines time to cleanup
+	// Let the monitors and any balance or delta check still running stop,
+	// then flush queued alerts, bounded by the shutdown timeout, before the
+	// Discord session is closed. An interrupted check sends its digest
+	// before it returns.
 	log.Println("Waiting for services to stop...")
-	time.Sleep(2 * time.Second)
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeoutSeconds)*time.Second)
+	defer cancelFlush()
+
+	stopped := make(chan struct{})
+	go func() {
+		services.Wait()
+		mon.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		mon.FlushNotifications(flushCtx)
+	case <-flushCtx.Done():
+		log.Println("WARNING: shutdown timeout reached before the balance check stopped")
+	}
+
+	if err := discordClient.Close(); err != nil {
+		log.Printf("Error closing Discord session: %v", err)
+	}
 
 	log.Println("Account monitor stopped")
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/networks"
+)
+
+const (
+	// Networks are rediscovered this often while discovery succeeds
+	networkRefreshInterval = 30 * time.Minute
+	// First retry after a failed discovery; each further failure doubles
+	// it, up to networkRefreshInterval
+	networkRefreshRetry = time.Minute
+	// Every delay is stretched by up to this fraction so instances don't
+	// refresh in lockstep
+	networkRefreshJitter = 0.2
+)
+
+// runNetworkRefresh rediscovers networks until ctx is canceled. A failed
+// discovery is retried with exponential backoff; a successful one resets
+// the delay to networkRefreshInterval.
+func runNetworkRefresh(ctx context.Context, networkMgr *networks.Manager) {
+	failures := 0
+	for {
+		delay := refreshDelay(failures)
+		if failures > 0 {
+			log.Printf("Retrying network refresh in %v", delay.Round(time.Second))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		log.Println("Refreshing network information...")
+		err := networkMgr.DiscoverNetworks(ctx)
+		switch {
+		case err == nil:
+			failures = 0
+		case errors.Is(err, context.Canceled):
+			return
+		default:
+			log.Printf("Network refresh error: %v", err)
+			failures++
+		}
+	}
+}
+
+// refreshDelay returns the jittered wait before the next refresh after the
+// given number of consecutive failures
+func refreshDelay(failures int) time.Duration {
+	delay := networkRefreshInterval
+	if failures > 0 {
+		delay = networkRefreshRetry
+		for i := 1; i < failures && delay < networkRefreshInterval; i++ {
+			delay *= 2
+		}
+		delay = min(delay, networkRefreshInterval)
+	}
+	return delay + time.Duration(rand.Float64()*networkRefreshJitter*float64(delay))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/stake-plus/account-manager/src/account-monitor/components/config"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/database"
+	"github.com/stake-plus/account-manager/src/account-monitor/components/discord"
+)
+
+// warnIfUnconfigured warns when there are no active networks or no monitored
+// accounts, since the monitor then runs without checking anything. The
+// warning is also sent to Discord when notifications are enabled.
+func warnIfUnconfigured(db *database.DB, discordClient *discord.Client, cfg *config.Config) {
+	var missing []string
+
+	networkList, err := db.GetNetworks()
+	if err != nil {
+		log.Printf("Failed to get networks: %v", err)
+	} else if len(networkList) == 0 {
+		missing = append(missing, "No active networks are configured: add rows to the `networks` table (active = TRUE).")
+	}
+
+	accounts, err := db.GetAccounts()
+	if err != nil {
+		log.Printf("Failed to get accounts: %v", err)
+	} else if len(accounts) == 0 {
+		missing = append(missing, "No accounts are monitored: add rows to the `accounts` table (monitor_enabled = TRUE) "+
+			"or run `account-monitor import <file>`.")
+	}
+
+	if len(missing) == 0 {
+		return
+	}
+
+	log.Println("********************************************************************")
+	log.Println("WARNING: Nothing will be monitored until this is fixed")
+	for _, line := range missing {
+		log.Printf("WARNING: %s", line)
+	}
+	log.Println("********************************************************************")
+
+	if cfg.EnableNotifications {
+		if err := discordClient.SendOperationalAlert("Nothing to monitor", strings.Join(missing, "\n")); err != nil {
+			log.Printf("Failed to send configuration warning: %v", err)
+		}
+	}
+}